@@ -0,0 +1,196 @@
+package line
+
+type ActionType string
+
+const (
+	PostbackActionType       ActionType = "postback"
+	MessageActionType        ActionType = "message"
+	URIActionType            ActionType = "uri"
+	DatetimePickerActionType ActionType = "datetimepicker"
+	CameraActionType         ActionType = "camera"
+	CameraRollActionType     ActionType = "cameraRoll"
+	LocationActionType       ActionType = "location"
+	RichMenuSwitchActionType ActionType = "richmenuswitch"
+	ClipboardActionType      ActionType = "clipboard"
+)
+
+type (
+	PostbackInputOption string
+	DatetimePickerMode  string
+)
+
+const (
+	PostbackInputOptionCloseRichMenu PostbackInputOption = "closeRichMenu"
+	PostbackInputOptionOpenRichMenu  PostbackInputOption = "openRichMenu"
+	PostbackInputOptionOpenKeyboard  PostbackInputOption = "openKeyboard"
+	PostbackInputOptionOpenVoice     PostbackInputOption = "openVoice"
+
+	DatetimePickerModeDate     DatetimePickerMode = "date"
+	DatetimePickerModeTime     DatetimePickerMode = "time"
+	DatetimePickerModeDatetime DatetimePickerMode = "datetime"
+)
+
+// Action https://developers.line.biz/en/reference/messaging-api/#action-objects
+//
+// Action is implemented by every action type and is used by templates, quick
+// replies, flex messages and rich menus.
+type Action interface {
+	ActionType() ActionType
+}
+
+// PostbackAction https://developers.line.biz/en/reference/messaging-api/#postback-action
+type PostbackAction struct {
+	Type        ActionType          `json:"type"`
+	Label       string              `json:"label,omitempty"`
+	Data        string              `json:"data"`
+	DisplayText string              `json:"displayText,omitempty"`
+	Text        string              `json:"text,omitempty"` // Deprecated: use DisplayText
+	InputOption PostbackInputOption `json:"inputOption,omitempty"`
+	FillInText  string              `json:"fillInText,omitempty"`
+}
+
+func (PostbackAction) ActionType() ActionType {
+	return PostbackActionType
+}
+
+// MessageAction https://developers.line.biz/en/reference/messaging-api/#message-action
+type MessageAction struct {
+	Type  ActionType `json:"type"`
+	Label string     `json:"label,omitempty"`
+	Text  string     `json:"text"`
+}
+
+func (MessageAction) ActionType() ActionType {
+	return MessageActionType
+}
+
+// URIAction https://developers.line.biz/en/reference/messaging-api/#uri-action
+type URIAction struct {
+	Type   ActionType `json:"type"`
+	Label  string     `json:"label,omitempty"`
+	URI    string     `json:"uri"`
+	AltURI *AltURI    `json:"altUri,omitempty"`
+}
+
+func (URIAction) ActionType() ActionType {
+	return URIActionType
+}
+
+// AltURI is the URI opened on LINE for macOS and Windows.
+type AltURI struct {
+	Desktop string `json:"desktop"`
+}
+
+// DatetimePickerAction https://developers.line.biz/en/reference/messaging-api/#datetime-picker-action
+type DatetimePickerAction struct {
+	Type    ActionType         `json:"type"`
+	Label   string             `json:"label,omitempty"`
+	Data    string             `json:"data"`
+	Mode    DatetimePickerMode `json:"mode"`
+	Initial string             `json:"initial,omitempty"`
+	Max     string             `json:"max,omitempty"`
+	Min     string             `json:"min,omitempty"`
+}
+
+func (DatetimePickerAction) ActionType() ActionType {
+	return DatetimePickerActionType
+}
+
+// CameraAction https://developers.line.biz/en/reference/messaging-api/#camera-action
+type CameraAction struct {
+	Type  ActionType `json:"type"`
+	Label string     `json:"label"`
+}
+
+func (CameraAction) ActionType() ActionType {
+	return CameraActionType
+}
+
+// CameraRollAction https://developers.line.biz/en/reference/messaging-api/#camera-roll-action
+type CameraRollAction struct {
+	Type  ActionType `json:"type"`
+	Label string     `json:"label"`
+}
+
+func (CameraRollAction) ActionType() ActionType {
+	return CameraRollActionType
+}
+
+// LocationAction https://developers.line.biz/en/reference/messaging-api/#location-action
+type LocationAction struct {
+	Type  ActionType `json:"type"`
+	Label string     `json:"label"`
+}
+
+func (LocationAction) ActionType() ActionType {
+	return LocationActionType
+}
+
+// RichMenuSwitchAction https://developers.line.biz/en/reference/messaging-api/#richmenu-switch-action
+type RichMenuSwitchAction struct {
+	Type            ActionType `json:"type"`
+	Label           string     `json:"label,omitempty"`
+	RichMenuAliasID string     `json:"richMenuAliasId"`
+	Data            string     `json:"data"`
+}
+
+func (RichMenuSwitchAction) ActionType() ActionType {
+	return RichMenuSwitchActionType
+}
+
+// ClipboardAction https://developers.line.biz/en/reference/messaging-api/#clipboard-action
+type ClipboardAction struct {
+	Type          ActionType `json:"type"`
+	Label         string     `json:"label"`
+	ClipboardText string     `json:"clipboardText"`
+}
+
+func (ClipboardAction) ActionType() ActionType {
+	return ClipboardActionType
+}
+
+// NewPostbackAction returns a postback action.
+func NewPostbackAction(label, data string) *PostbackAction {
+	return &PostbackAction{Type: PostbackActionType, Label: label, Data: data}
+}
+
+// NewMessageAction returns a message action.
+func NewMessageAction(label, text string) *MessageAction {
+	return &MessageAction{Type: MessageActionType, Label: label, Text: text}
+}
+
+// NewURIAction returns a URI action.
+func NewURIAction(label, uri string) *URIAction {
+	return &URIAction{Type: URIActionType, Label: label, URI: uri}
+}
+
+// NewDatetimePickerAction returns a datetime picker action.
+func NewDatetimePickerAction(label, data string, mode DatetimePickerMode) *DatetimePickerAction {
+	return &DatetimePickerAction{Type: DatetimePickerActionType, Label: label, Data: data, Mode: mode}
+}
+
+// NewCameraAction returns a camera action. Only usable in quick replies.
+func NewCameraAction(label string) *CameraAction {
+	return &CameraAction{Type: CameraActionType, Label: label}
+}
+
+// NewCameraRollAction returns a camera roll action. Only usable in quick replies.
+func NewCameraRollAction(label string) *CameraRollAction {
+	return &CameraRollAction{Type: CameraRollActionType, Label: label}
+}
+
+// NewLocationAction returns a location action. Only usable in quick replies.
+func NewLocationAction(label string) *LocationAction {
+	return &LocationAction{Type: LocationActionType, Label: label}
+}
+
+// NewRichMenuSwitchAction returns a rich menu switch action. Only usable in
+// rich menus.
+func NewRichMenuSwitchAction(label, richMenuAliasID, data string) *RichMenuSwitchAction {
+	return &RichMenuSwitchAction{Type: RichMenuSwitchActionType, Label: label, RichMenuAliasID: richMenuAliasID, Data: data}
+}
+
+// NewClipboardAction returns a clipboard action.
+func NewClipboardAction(label, clipboardText string) *ClipboardAction {
+	return &ClipboardAction{Type: ClipboardActionType, Label: label, ClipboardText: clipboardText}
+}
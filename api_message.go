@@ -9,8 +9,12 @@ type MessageService struct {
 	client *Client
 }
 
+// Push sends messages to a single user, group or chat room. A retry key is
+// attached automatically; supply WithRetryKey to control it. If the retry key
+// was already accepted, no error is returned and Response.RetryKeyAccepted
+// reports true.
 func (b *MessageService) Push(ctx context.Context, opt MessagePushOptions, options ...RequestOptionFunc) (*MessagesResponse, *Response, error) {
-	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/push", opt, options)
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/push", opt, withDefaultRetryKey(options))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -38,3 +42,137 @@ func (b *MessageService) ValidatePush(ctx context.Context, opt ValidateMessagePu
 
 	return m, resp, nil
 }
+
+func (b *MessageService) Reply(ctx context.Context, opt MessageReplyOptions, options ...RequestOptionFunc) (*MessagesResponse, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/reply", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(MessagesResponse)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+func (b *MessageService) ValidateReply(ctx context.Context, opt ValidateMessageReplyOptions, options ...RequestOptionFunc) (*ValidatePushResponse, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/validate/reply", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(ValidatePushResponse)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+func (b *MessageService) Multicast(ctx context.Context, opt MessageMulticastOptions, options ...RequestOptionFunc) (*Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/multicast", opt, withDefaultRetryKey(options))
+	if err != nil {
+		return nil, err
+	}
+
+	return b.client.Do(req, nil)
+}
+
+func (b *MessageService) ValidateMulticast(ctx context.Context, opt ValidateMessageMulticastOptions, options ...RequestOptionFunc) (*ValidatePushResponse, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/validate/multicast", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(ValidatePushResponse)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// Narrowcast sends the messages asynchronously. The returned RequestID is
+// taken from the X-Line-Request-Id header and can be passed to
+// NarrowcastProgress to follow the delivery. When the retry key was already
+// accepted, RequestID refers to the original request instead.
+func (b *MessageService) Narrowcast(ctx context.Context, opt MessageNarrowcastOptions, options ...RequestOptionFunc) (*NarrowcastResponse, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/narrowcast", opt, withDefaultRetryKey(options))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := b.client.Do(req, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := &NarrowcastResponse{RequestID: resp.Header.Get("X-Line-Request-Id")}
+	if resp.RetryKeyAccepted() {
+		m.RequestID = resp.AcceptedRequestID
+	}
+	return m, resp, nil
+}
+
+func (b *MessageService) ValidateNarrowcast(ctx context.Context, opt ValidateMessageNarrowcastOptions, options ...RequestOptionFunc) (*ValidatePushResponse, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/validate/narrowcast", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(ValidatePushResponse)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+func (b *MessageService) NarrowcastProgress(ctx context.Context, requestID string, options ...RequestOptionFunc) (*NarrowcastProgressResponse, *Response, error) {
+	opt := struct {
+		RequestID string `url:"requestId"`
+	}{RequestID: requestID}
+
+	req, err := b.client.NewRequest(ctx, http.MethodGet, "bot/message/progress/narrowcast", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(NarrowcastProgressResponse)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+func (b *MessageService) Broadcast(ctx context.Context, opt MessageBroadcastOptions, options ...RequestOptionFunc) (*Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/broadcast", opt, withDefaultRetryKey(options))
+	if err != nil {
+		return nil, err
+	}
+
+	return b.client.Do(req, nil)
+}
+
+func (b *MessageService) ValidateBroadcast(ctx context.Context, opt ValidateMessageBroadcastOptions, options ...RequestOptionFunc) (*ValidatePushResponse, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bot/message/validate/broadcast", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(ValidatePushResponse)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
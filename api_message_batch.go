@@ -0,0 +1,122 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MaxMulticastRecipients is the maximum number of user IDs accepted by
+	// a single multicast request.
+	MaxMulticastRecipients = 500
+
+	defaultMulticastConcurrency = 4
+)
+
+// MessageMulticastBatchOptions describes a multicast to an arbitrary number of
+// recipients. To is split into batches of at most BatchSize user IDs.
+type MessageMulticastBatchOptions struct {
+	MessageMulticastOptions
+	BatchSize   int // Defaults to MaxMulticastRecipients
+	Concurrency int // Number of batches sent at the same time, defaults to 4
+}
+
+// MulticastBatchResult is the outcome of a single multicast batch.
+type MulticastBatchResult struct {
+	Index int
+	To    []string
+	// RetryKey is the X-Line-Retry-Key the batch was sent with. Resend a
+	// failed batch with WithRetryKey(RetryKey) so that LINE delivers it at
+	// most once, even if the failed attempt was accepted after all.
+	RetryKey string
+	Response *Response
+	Err      error
+}
+
+// MulticastBatchReport collects the results of every batch sent by
+// MulticastBatch, in batch order.
+type MulticastBatchReport struct {
+	Batches []MulticastBatchResult
+}
+
+// Failed returns the batches that could not be sent.
+func (r *MulticastBatchReport) Failed() []MulticastBatchResult {
+	var failed []MulticastBatchResult
+	for _, b := range r.Batches {
+		if b.Err != nil {
+			failed = append(failed, b)
+		}
+	}
+	return failed
+}
+
+// FailedRecipients returns the user IDs of every failed batch, so that they
+// can be retried without re-sending to everyone.
+func (r *MulticastBatchReport) FailedRecipients() []string {
+	var to []string
+	for _, b := range r.Failed() {
+		to = append(to, b.To...)
+	}
+	return to
+}
+
+// Err joins the errors of all failed batches, or returns nil if every batch
+// was sent.
+func (r *MulticastBatchReport) Err() error {
+	var errs []error
+	for _, b := range r.Failed() {
+		errs = append(errs, fmt.Errorf("line: multicast batch %d: %w", b.Index, b.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// MulticastBatch splits the recipients into API sized batches and sends them
+// with bounded concurrency. A failing batch does not stop the others; inspect
+// the returned report to find out which batches need to be retried.
+//
+// Every batch gets its own retry key, reported in MulticastBatchResult. A
+// retry key set through options is overridden, since LINE would accept only
+// the first batch sent with it.
+func (b *MessageService) MulticastBatch(ctx context.Context, opt MessageMulticastBatchOptions, options ...RequestOptionFunc) *MulticastBatchReport {
+	size := opt.BatchSize
+	if size <= 0 || size > MaxMulticastRecipients {
+		size = MaxMulticastRecipients
+	}
+	concurrency := opt.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultMulticastConcurrency
+	}
+
+	var batches [][]string
+	for to := opt.To; len(to) > 0; {
+		n := min(size, len(to))
+		batches = append(batches, to[:n:n])
+		to = to[n:]
+	}
+
+	report := &MulticastBatchReport{Batches: make([]MulticastBatchResult, len(batches))}
+
+	var eg errgroup.Group
+	eg.SetLimit(concurrency)
+	for i, to := range batches {
+		eg.Go(func() error {
+			result := MulticastBatchResult{Index: i, To: to, RetryKey: NewRetryKey()}
+			if err := ctx.Err(); err != nil {
+				result.Err = err
+			} else {
+				batch := opt.MessageMulticastOptions
+				batch.To = to
+				batchOptions := append(options[:len(options):len(options)], WithRetryKey(result.RetryKey))
+				result.Response, result.Err = b.Multicast(ctx, batch, batchOptions...)
+			}
+			report.Batches[i] = result
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	return report
+}
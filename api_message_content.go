@@ -0,0 +1,58 @@
+package line
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Content streams the content of an image, video, audio or file message sent
+// by a user into w.
+// https://developers.line.biz/en/reference/messaging-api/#get-content
+func (b *MessageService) Content(ctx context.Context, messageID string, w io.Writer, options ...RequestOptionFunc) (*MessageContent, *Response, error) {
+	return b.content(ctx, fmt.Sprintf("bot/message/%s/content", messageID), w, options)
+}
+
+// ContentPreview streams the preview image of an image or video message into w.
+// https://developers.line.biz/en/reference/messaging-api/#get-image-or-video-preview
+func (b *MessageService) ContentPreview(ctx context.Context, messageID string, w io.Writer, options ...RequestOptionFunc) (*MessageContent, *Response, error) {
+	return b.content(ctx, fmt.Sprintf("bot/message/%s/content/preview", messageID), w, options)
+}
+
+// ContentTranscoding reports whether the content of a video or audio message
+// is ready to be downloaded.
+// https://developers.line.biz/en/reference/messaging-api/#verify-video-or-audio-preparation-status
+func (b *MessageService) ContentTranscoding(ctx context.Context, messageID string, options ...RequestOptionFunc) (*MessageContentTranscoding, *Response, error) {
+	u := fmt.Sprintf("bot/message/%s/content/transcoding", messageID)
+	req, err := b.client.NewDataRequest(ctx, http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(MessageContentTranscoding)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+func (b *MessageService) content(ctx context.Context, u string, w io.Writer, options []RequestOptionFunc) (*MessageContent, *Response, error) {
+	req, err := b.client.NewDataRequest(ctx, http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := b.client.Do(req, w)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := &MessageContent{
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}
+	return m, resp, nil
+}
@@ -0,0 +1,71 @@
+package line
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type DeliveryType string
+
+const (
+	ReplyDeliveryType     DeliveryType = "reply"
+	PushDeliveryType      DeliveryType = "push"
+	MulticastDeliveryType DeliveryType = "multicast"
+	BroadcastDeliveryType DeliveryType = "broadcast"
+)
+
+// DeliveryCountOptions https://developers.line.biz/en/reference/messaging-api/#get-number-of-reply-messages
+type DeliveryCountOptions struct {
+	Date string `url:"date"` // yyyyMMdd, UTC+9
+}
+
+// Quota https://developers.line.biz/en/reference/messaging-api/#get-quota
+func (b *MessageService) Quota(ctx context.Context, options ...RequestOptionFunc) (*MessageQuota, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodGet, "bot/message/quota", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(MessageQuota)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// QuotaConsumption https://developers.line.biz/en/reference/messaging-api/#get-consumption
+func (b *MessageService) QuotaConsumption(ctx context.Context, options ...RequestOptionFunc) (*MessageQuotaConsumption, *Response, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodGet, "bot/message/quota/consumption", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(MessageQuotaConsumption)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// DeliveryCount returns the number of messages sent with the given delivery
+// type on the given date.
+func (b *MessageService) DeliveryCount(ctx context.Context, deliveryType DeliveryType, opt DeliveryCountOptions, options ...RequestOptionFunc) (*MessageDeliveryCount, *Response, error) {
+	u := fmt.Sprintf("bot/message/delivery/%s", deliveryType)
+	req, err := b.client.NewRequest(ctx, http.MethodGet, u, opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(MessageDeliveryCount)
+	resp, err := b.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
@@ -1,13 +1,16 @@
 package line
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -69,6 +72,361 @@ func Test_ValidatePush(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_Reply(t *testing.T) {
+	// 创建一个模拟的 HTTP 服务器
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/bot/message/reply", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req MessageReplyOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", req.ReplyToken)
+		assert.True(t, req.NotificationDisabled)
+
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, json.NewEncoder(w).Encode(MessagesResponse{
+			SentMessages: []SentMessage{{ID: "461230966842064897", QuoteToken: "IStG5h1Tz7b"}},
+		}))
+	}))
+	defer ts.Close()
+
+	// 创建 Client 并设置 baseURL 为模拟服务器的 URL
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	// 调用 Reply 方法
+	opt := MessageReplyOptions{
+		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+		Messages: []Message{
+			TextMessage{Type: TextMessageType, Text: "Hello, World!"},
+		},
+		NotificationDisabled: true,
+	}
+
+	m, _, err := client.Message.Reply(context.Background(), opt)
+	require.NoError(t, err)
+	require.Len(t, m.SentMessages, 1)
+	assert.Equal(t, "461230966842064897", m.SentMessages[0].ID)
+	assert.Equal(t, "IStG5h1Tz7b", m.SentMessages[0].QuoteToken)
+}
+
+func Test_ValidateReply(t *testing.T) {
+	// 创建一个模拟的 HTTP 服务器
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/bot/message/validate/reply", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, json.NewEncoder(w).Encode(ValidatePushResponse{}))
+	}))
+	defer ts.Close()
+
+	// 创建 Client 并设置 baseURL 为模拟服务器的 URL
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	opt := ValidateMessageReplyOptions{
+		Messages: []Message{
+			TextMessage{Type: TextMessageType, Text: "Hello, World!"},
+		},
+	}
+	_, _, err = client.Message.ValidateReply(context.Background(), opt)
+	assert.NoError(t, err)
+}
+
+func Test_Multicast(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/bot/message/multicast", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req MessageMulticastOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []string{"U1", "U2"}, req.To)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	opt := MessageMulticastOptions{
+		To: []string{"U1", "U2"},
+		Messages: []Message{
+			TextMessage{Type: TextMessageType, Text: "Hello, World!"},
+		},
+	}
+	_, err = client.Message.Multicast(context.Background(), opt)
+	require.NoError(t, err)
+}
+
+func Test_Narrowcast(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/bot/message/narrowcast":
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			var req map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, map[string]interface{}{
+				"type": "operator",
+				"and": []interface{}{
+					map[string]interface{}{"type": "audience", "audienceGroupId": float64(5614991017776)},
+				},
+			}, req["recipient"])
+			assert.Equal(t, map[string]interface{}{"max": float64(100)}, req["limit"])
+
+			w.Header().Set("X-Line-Request-Id", "req-123")
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("{}"))
+		case "/v2/bot/message/progress/narrowcast":
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "req-123", r.URL.Query().Get("requestId"))
+
+			assert.NoError(t, json.NewEncoder(w).Encode(NarrowcastProgressResponse{Phase: "succeeded", SuccessCount: 10, TargetCount: 10}))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	opt := MessageNarrowcastOptions{
+		Messages: []Message{
+			TextMessage{Type: TextMessageType, Text: "Hello, World!"},
+		},
+		Recipient: OperatorRecipient{
+			Type: OperatorRecipientType,
+			And: []Recipient{
+				AudienceRecipient{Type: AudienceRecipientType, AudienceGroupID: 5614991017776},
+			},
+		},
+		Filter: &NarrowcastFilter{
+			Demographic: GenderDemographicFilter{Type: GenderDemographicFilterType, OneOf: []string{"male"}},
+		},
+		Limit: &NarrowcastLimit{Max: 100},
+	}
+	m, _, err := client.Message.Narrowcast(context.Background(), opt)
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", m.RequestID)
+
+	progress, _, err := client.Message.NarrowcastProgress(context.Background(), m.RequestID)
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", progress.Phase)
+	assert.Equal(t, int64(10), progress.SuccessCount)
+}
+
+func Test_MulticastBatch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/bot/message/multicast", r.URL.Path)
+
+		var req MessageMulticastOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.LessOrEqual(t, len(req.To), 2)
+
+		if req.To[0] == "U3" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"The request body has 1 error(s)"}`))
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	opt := MessageMulticastBatchOptions{
+		MessageMulticastOptions: MessageMulticastOptions{
+			To: []string{"U1", "U2", "U3", "U4", "U5"},
+			Messages: []Message{
+				TextMessage{Type: TextMessageType, Text: "Hello, World!"},
+			},
+		},
+		BatchSize:   2,
+		Concurrency: 2,
+	}
+	report := client.Message.MulticastBatch(context.Background(), opt)
+
+	require.Len(t, report.Batches, 3)
+	assert.Equal(t, []string{"U5"}, report.Batches[2].To)
+	require.Len(t, report.Failed(), 1)
+	assert.Equal(t, 1, report.Failed()[0].Index)
+	assert.Equal(t, []string{"U3", "U4"}, report.FailedRecipients())
+	assert.Error(t, report.Err())
+}
+
+func Test_MulticastBatchRetryKeys(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys = map[string][]string{}
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req MessageMulticastOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		mu.Lock()
+		defer mu.Unlock()
+		key := r.Header.Get("X-Line-Retry-Key")
+		if _, ok := keys[key]; ok {
+			w.Header().Set("X-Line-Accepted-Request-Id", "accepted-req-id")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"The retry key is already accepted"}`))
+			return
+		}
+		keys[key] = req.To
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	opt := MessageMulticastBatchOptions{
+		MessageMulticastOptions: MessageMulticastOptions{
+			To:       []string{"U1", "U2", "U3", "U4"},
+			Messages: Messages{NewTextMessage("Hello, World!")},
+		},
+		BatchSize: 1,
+	}
+	// A caller supplied key must not make LINE drop batches 2..n as duplicates.
+	report := client.Message.MulticastBatch(context.Background(), opt, WithRetryKey("caller-key"))
+	require.NoError(t, report.Err())
+
+	require.Len(t, keys, 4)
+	assert.NotContains(t, keys, "caller-key")
+	for _, b := range report.Batches {
+		assert.False(t, b.Response.RetryKeyAccepted())
+		assert.Equal(t, b.To, keys[b.RetryKey])
+	}
+
+	// Resending a batch with its retry key is not delivered twice.
+	batch := report.Batches[0]
+	resp, err := client.Message.Multicast(context.Background(),
+		MessageMulticastOptions{To: batch.To, Messages: opt.Messages}, WithRetryKey(batch.RetryKey))
+	require.NoError(t, err)
+	assert.True(t, resp.RetryKeyAccepted())
+}
+
+func Test_PushRetryKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("X-Line-Retry-Key"))
+
+		switch len(keys) {
+		case 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Header().Set("X-Line-Accepted-Request-Id", "accepted-req-id")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"The retry key is already accepted","sentMessages":[{"id":"461230966842064897","quoteToken":"IStG5h1Tz7b"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	httpClient := NewRetryableHTTPClient(
+		WithRetryableHTTPClientRetryWaitMin(time.Millisecond),
+		WithRetryableHTTPClientRetryWaitMax(time.Millisecond),
+	)
+	client, err := NewClient("test-token", WithBaseURL(ts.URL), WithClient(httpClient))
+	require.NoError(t, err)
+
+	opt := MessagePushOptions{
+		To: "U1234567890",
+		Messages: []Message{
+			TextMessage{Type: TextMessageType, Text: "Hello, World!"},
+		},
+	}
+
+	m, resp, err := client.Message.Push(context.Background(), opt)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.True(t, resp.RetryKeyAccepted())
+	assert.Equal(t, "accepted-req-id", resp.AcceptedRequestID)
+	require.Len(t, m.SentMessages, 1)
+
+	keys = nil
+	_, _, err = client.Message.Push(context.Background(), opt, WithRetryKey("123e4567-e89b-12d3-a456-426614174000"))
+	require.NoError(t, err)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", keys[0])
+}
+
+func Test_Quota(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/v2/bot/message/quota":
+			_, _ = w.Write([]byte(`{"type":"limited","value":1000}`))
+		case "/v2/bot/message/quota/consumption":
+			_, _ = w.Write([]byte(`{"totalUsage":500}`))
+		case "/v2/bot/message/delivery/push":
+			assert.Equal(t, "20241016", r.URL.Query().Get("date"))
+			_, _ = w.Write([]byte(`{"status":"ready","success":42}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	quota, _, err := client.Message.Quota(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, MessageQuota{Type: "limited", Value: 1000}, *quota)
+
+	consumption, _, err := client.Message.QuotaConsumption(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), consumption.TotalUsage)
+
+	count, _, err := client.Message.DeliveryCount(context.Background(), PushDeliveryType, DeliveryCountOptions{Date: "20241016"})
+	require.NoError(t, err)
+	assert.Equal(t, MessageDeliveryCount{Status: "ready", Success: 42}, *count)
+}
+
+func Test_Content(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to api host: %s", r.URL.Path)
+	}))
+	defer api.Close()
+
+	data := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/v2/bot/message/325708/content":
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("jpeg-bytes"))
+		case "/v2/bot/message/325708/content/transcoding":
+			_, _ = w.Write([]byte(`{"status":"succeeded"}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer data.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(api.URL), WithDataBaseURL(data.URL))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	content, _, err := client.Message.Content(context.Background(), "325708", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg-bytes", buf.String())
+	assert.Equal(t, "image/jpeg", content.ContentType)
+	assert.Equal(t, int64(len("jpeg-bytes")), content.ContentLength)
+
+	transcoding, _, err := client.Message.ContentTranscoding(context.Background(), "325708")
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", transcoding.Status)
+}
+
 func TestParseTextMessage(t *testing.T) {
 	message := `{"to":"U1234567890","messages":[{"type":"text","text":"Hello, World!"}]}`
 	expected := MessagePushOptions{
@@ -219,23 +577,23 @@ func TestParseTemplateMessage(t *testing.T) {
 					ImageBackgroundColor: "#FFFFFF",
 					Title:                "Menu",
 					Text:                 "Please select",
-					DefaultAction: &DefaultAction{
+					DefaultAction: &URIAction{
 						Type:  "uri",
 						Label: "View detail",
 						URI:   "http://example.com/page/123",
 					},
 					Actions: []Action{
-						{
+						&PostbackAction{
 							Type:  "postback",
 							Label: "Buy",
 							Data:  "action=buy&itemid=123",
 						},
-						{
+						&PostbackAction{
 							Type:  "postback",
 							Label: "Add to cart",
 							Data:  "action=add&itemid=123",
 						},
-						{
+						&URIAction{
 							Type:  "uri",
 							Label: "View detail",
 							URI:   "http://example.com/page/123",
@@ -250,12 +608,12 @@ func TestParseTemplateMessage(t *testing.T) {
 					Type: "confirm",
 					Text: "Are you sure?",
 					Actions: []Action{
-						{
+						&MessageAction{
 							Type:  "message",
 							Label: "Yes",
 							Text:  "yes",
 						},
-						{
+						&MessageAction{
 							Type:  "message",
 							Label: "No",
 							Text:  "no",
@@ -276,23 +634,23 @@ func TestParseTemplateMessage(t *testing.T) {
 							ImageBackgroundColor: "#FFFFFF",
 							Title:                "this is menu",
 							Text:                 "description",
-							DefaultAction: &DefaultAction{
+							DefaultAction: &URIAction{
 								Type:  "uri",
 								Label: "View detail",
 								URI:   "http://example.com/page/123",
 							},
 							Actions: []Action{
-								{
+								&PostbackAction{
 									Type:  "postback",
 									Label: "Buy",
 									Data:  "action=buy&itemid=111",
 								},
-								{
+								&PostbackAction{
 									Type:  "postback",
 									Label: "Add to cart",
 									Data:  "action=add&itemid=111",
 								},
-								{
+								&URIAction{
 									Type:  "uri",
 									Label: "View detail",
 									URI:   "http://example.com/page/111",
@@ -304,23 +662,23 @@ func TestParseTemplateMessage(t *testing.T) {
 							ImageBackgroundColor: "#000000",
 							Title:                "this is menu",
 							Text:                 "description",
-							DefaultAction: &DefaultAction{
+							DefaultAction: &URIAction{
 								Type:  "uri",
 								Label: "View detail",
 								URI:   "http://example.com/page/222",
 							},
 							Actions: []Action{
-								{
+								&PostbackAction{
 									Type:  "postback",
 									Label: "Buy",
 									Data:  "action=buy&itemid=222",
 								},
-								{
+								&PostbackAction{
 									Type:  "postback",
 									Label: "Add to cart",
 									Data:  "action=add&itemid=222",
 								},
-								{
+								&URIAction{
 									Type:  "uri",
 									Label: "View detail",
 									URI:   "http://example.com/page/222",
@@ -338,7 +696,7 @@ func TestParseTemplateMessage(t *testing.T) {
 					Columns: []ImageCarouselColumn{
 						{
 							ImageURL: "https://example.com/bot/images/item1.jpg",
-							Action: Action{
+							Action: &PostbackAction{
 								Type:  "postback",
 								Label: "Buy",
 								Data:  "action=buy&itemid=111",
@@ -346,7 +704,7 @@ func TestParseTemplateMessage(t *testing.T) {
 						},
 						{
 							ImageURL: "https://example.com/bot/images/item2.jpg",
-							Action: Action{
+							Action: &MessageAction{
 								Type:  "message",
 								Label: "Yes",
 								Text:  "yes",
@@ -354,7 +712,7 @@ func TestParseTemplateMessage(t *testing.T) {
 						},
 						{
 							ImageURL: "https://example.com/bot/images/item3.jpg",
-							Action: Action{
+							Action: &URIAction{
 								Type:  "uri",
 								Label: "View detail",
 								URI:   "http://example.com/page/222",
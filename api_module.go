@@ -0,0 +1,50 @@
+package line
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ModuleService calls the APIs of a module channel attached to a LINE
+// Official Account. Use the module channel's access token.
+// https://developers.line.biz/en/reference/partner-docs/#module
+type ModuleService struct {
+	client *Client
+}
+
+type AcquireChatControlOptions struct {
+	// Expired tells whether chat control returns to the Primary Channel after
+	// TTL. Defaults to true on LINE's side when the body is omitted.
+	Expired bool `json:"expired"`
+	TTL     int  `json:"ttl,omitempty"` // Seconds, at most one year
+}
+
+// AcquireChatControl takes chat control of a user, group or room from the
+// Primary Channel. opt may be nil.
+// https://developers.line.biz/en/reference/partner-docs/#acquire-control-api
+func (s *ModuleService) AcquireChatControl(ctx context.Context, chatID string, opt *AcquireChatControlOptions, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/chat/%s/control/acquire", chatID)
+	var body interface{}
+	if opt != nil {
+		body = opt
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, body, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// ReleaseChatControl hands chat control back to the Primary Channel.
+// https://developers.line.biz/en/reference/partner-docs/#release-control-api
+func (s *ModuleService) ReleaseChatControl(ctx context.Context, chatID string, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/chat/%s/control/release", chatID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
@@ -0,0 +1,39 @@
+package line
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ChatControl(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, _ := io.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/v2/bot/chat/U1/control/acquire":
+			assert.JSONEq(t, `{"expired":true,"ttl":3600}`, string(body))
+		case "/v2/bot/chat/C1/control/acquire", "/v2/bot/chat/U1/control/release":
+			assert.Empty(t, body)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("module-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	_, err = client.Module.AcquireChatControl(context.Background(), "U1", &AcquireChatControlOptions{Expired: true, TTL: 3600})
+	require.NoError(t, err)
+	_, err = client.Module.AcquireChatControl(context.Background(), "C1", nil)
+	require.NoError(t, err)
+	_, err = client.Module.ReleaseChatControl(context.Background(), "U1")
+	require.NoError(t, err)
+}
@@ -0,0 +1,191 @@
+package line
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// OAuthService issues, verifies and revokes channel access tokens. Its
+// requests are sent without an Authorization header, so it can be used from
+// a client that has no token yet.
+// https://developers.line.biz/en/reference/messaging-api/#channel-access-token
+type OAuthService struct {
+	client *Client
+}
+
+type IssueShortLivedTokenOptions struct {
+	ClientID     string `url:"client_id"`
+	ClientSecret string `url:"client_secret"`
+}
+
+type IssueTokenOptions struct {
+	ClientAssertion string `url:"client_assertion"` // JWT signed with the assertion signing key
+}
+
+type IssueStatelessTokenOptions struct {
+	ClientID        string `url:"client_id,omitempty"`
+	ClientSecret    string `url:"client_secret,omitempty"`
+	ClientAssertion string `url:"client_assertion,omitempty"`
+}
+
+type RevokeTokenOptions struct {
+	ClientID     string `url:"client_id"`
+	ClientSecret string `url:"client_secret"`
+	AccessToken  string `url:"access_token"`
+}
+
+// IssueShortLivedToken issues a short-lived channel access token, valid for 30 days.
+// https://developers.line.biz/en/reference/messaging-api/#issue-shortlived-channel-access-token
+func (s *OAuthService) IssueShortLivedToken(ctx context.Context, opt IssueShortLivedTokenOptions, options ...RequestOptionFunc) (*ChannelAccessToken, *Response, error) {
+	values, err := query.Values(opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	values.Set("grant_type", "client_credentials")
+
+	return s.issue(ctx, apiVersionPath, "oauth/accessToken", values, options)
+}
+
+// VerifyShortLivedToken https://developers.line.biz/en/reference/messaging-api/#verfiy-channel-access-token
+func (s *OAuthService) VerifyShortLivedToken(ctx context.Context, accessToken string, options ...RequestOptionFunc) (*VerifiedAccessToken, *Response, error) {
+	values := url.Values{"access_token": {accessToken}}
+	req, err := s.newRequest(ctx, http.MethodPost, apiVersionPath, "oauth/verify", values, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(VerifiedAccessToken)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// RevokeShortLivedToken https://developers.line.biz/en/reference/messaging-api/#revoke-longlived-or-shortlived-channel-access-token
+func (s *OAuthService) RevokeShortLivedToken(ctx context.Context, accessToken string, options ...RequestOptionFunc) (*Response, error) {
+	values := url.Values{"access_token": {accessToken}}
+	req, err := s.newRequest(ctx, http.MethodPost, apiVersionPath, "oauth/revoke", values, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// IssueToken issues a channel access token v2.1 using a JWT assertion, see
+// ClientAssertion.
+// https://developers.line.biz/en/reference/messaging-api/#issue-channel-access-token-v2-1
+func (s *OAuthService) IssueToken(ctx context.Context, opt IssueTokenOptions, options ...RequestOptionFunc) (*ChannelAccessToken, *Response, error) {
+	values, err := query.Values(opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	values.Set("grant_type", "client_credentials")
+	values.Set("client_assertion_type", clientAssertionType)
+
+	return s.issue(ctx, "", "oauth2/v2.1/token", values, options)
+}
+
+// VerifyToken verifies a channel access token v2.1 or a stateless token.
+// https://developers.line.biz/en/reference/messaging-api/#verfiy-channel-access-token-v2-1
+func (s *OAuthService) VerifyToken(ctx context.Context, accessToken string, options ...RequestOptionFunc) (*VerifiedAccessToken, *Response, error) {
+	opt := struct {
+		AccessToken string `url:"access_token"`
+	}{AccessToken: accessToken}
+
+	req, err := s.newRequest(ctx, http.MethodGet, "", "oauth2/v2.1/verify", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(VerifiedAccessToken)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// RevokeToken https://developers.line.biz/en/reference/messaging-api/#revoke-channel-access-token-v2-1
+func (s *OAuthService) RevokeToken(ctx context.Context, opt RevokeTokenOptions, options ...RequestOptionFunc) (*Response, error) {
+	values, err := query.Values(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPost, "", "oauth2/v2.1/revoke", values, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// TokenKeyIDs lists the key IDs of all valid channel access tokens v2.1.
+// https://developers.line.biz/en/reference/messaging-api/#get-all-valid-channel-access-token-key-ids-v2-1
+func (s *OAuthService) TokenKeyIDs(ctx context.Context, clientAssertion string, options ...RequestOptionFunc) (*ChannelAccessTokenKeyIDs, *Response, error) {
+	opt := struct {
+		ClientAssertionType string `url:"client_assertion_type"`
+		ClientAssertion     string `url:"client_assertion"`
+	}{ClientAssertionType: clientAssertionType, ClientAssertion: clientAssertion}
+
+	req, err := s.newRequest(ctx, http.MethodGet, "", "oauth2/v2.1/tokens/kid", opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(ChannelAccessTokenKeyIDs)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// IssueStatelessToken issues a stateless channel access token, valid for 15
+// minutes, using either the channel secret or a JWT assertion.
+// https://developers.line.biz/en/reference/messaging-api/#issue-stateless-channel-access-token
+func (s *OAuthService) IssueStatelessToken(ctx context.Context, opt IssueStatelessTokenOptions, options ...RequestOptionFunc) (*ChannelAccessToken, *Response, error) {
+	values, err := query.Values(opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	values.Set("grant_type", "client_credentials")
+	if opt.ClientAssertion != "" {
+		values.Set("client_assertion_type", clientAssertionType)
+	}
+
+	return s.issue(ctx, "", "oauth2/v3/token", values, options)
+}
+
+func (s *OAuthService) issue(ctx context.Context, versionPath, path string, values url.Values, options []RequestOptionFunc) (*ChannelAccessToken, *Response, error) {
+	req, err := s.newRequest(ctx, http.MethodPost, versionPath, path, values, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(ChannelAccessToken)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+func (s *OAuthService) newRequest(ctx context.Context, method, versionPath, path string, opt interface{}, options []RequestOptionFunc) (*http.Request, error) {
+	req, err := s.client.newRequest(ctx, s.client.baseURL, versionPath, method, path, opt, options)
+	if err != nil {
+		return nil, err
+	}
+	return withoutAuth(req), nil
+}
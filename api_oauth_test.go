@@ -0,0 +1,184 @@
+package line
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/v2/oauth/accessToken":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+			if !assert.NoError(t, r.ParseForm()) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "1234", r.PostForm.Get("client_id"))
+			assert.Equal(t, "secret", r.PostForm.Get("client_secret"))
+			_, _ = w.Write([]byte(`{"access_token":"short","expires_in":2592000,"token_type":"Bearer"}`))
+		case "/oauth2/v2.1/token":
+			if !assert.NoError(t, r.ParseForm()) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, clientAssertionType, r.PostForm.Get("client_assertion_type"))
+			assert.Equal(t, "jwt", r.PostForm.Get("client_assertion"))
+			_, _ = w.Write([]byte(`{"access_token":"v21","expires_in":2592000,"token_type":"Bearer","key_id":"kid"}`))
+		case "/oauth2/v2.1/verify":
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "v21", r.URL.Query().Get("access_token"))
+			_, _ = w.Write([]byte(`{"client_id":"1234","expires_in":100,"scope":"profile chat_message.write"}`))
+		case "/oauth2/v2.1/tokens/kid":
+			assert.Equal(t, "jwt", r.URL.Query().Get("client_assertion"))
+			_, _ = w.Write([]byte(`{"kids":["a","b"]}`))
+		case "/oauth2/v3/token":
+			if !assert.NoError(t, r.ParseForm()) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Empty(t, r.PostForm.Get("client_assertion_type"))
+			_, _ = w.Write([]byte(`{"access_token":"stateless","expires_in":900,"token_type":"Bearer"}`))
+		case "/oauth2/v2.1/revoke":
+			if !assert.NoError(t, r.ParseForm()) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, "v21", r.PostForm.Get("access_token"))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	token, _, err := client.OAuth.IssueShortLivedToken(ctx, IssueShortLivedTokenOptions{ClientID: "1234", ClientSecret: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, ChannelAccessToken{AccessToken: "short", ExpiresIn: 2592000, TokenType: "Bearer"}, *token)
+
+	token, _, err = client.OAuth.IssueToken(ctx, IssueTokenOptions{ClientAssertion: "jwt"})
+	require.NoError(t, err)
+	assert.Equal(t, "kid", token.KeyID)
+
+	verified, _, err := client.OAuth.VerifyToken(ctx, "v21")
+	require.NoError(t, err)
+	assert.Equal(t, VerifiedAccessToken{ClientID: "1234", ExpiresIn: 100, Scope: "profile chat_message.write"}, *verified)
+
+	kids, _, err := client.OAuth.TokenKeyIDs(ctx, "jwt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, kids.Kids)
+
+	token, _, err = client.OAuth.IssueStatelessToken(ctx, IssueStatelessTokenOptions{ClientID: "1234", ClientSecret: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "stateless", token.AccessToken)
+
+	_, err = client.OAuth.RevokeToken(ctx, RevokeTokenOptions{ClientID: "1234", ClientSecret: "secret", AccessToken: "v21"})
+	require.NoError(t, err)
+}
+
+func Test_TokenSourceRefresh(t *testing.T) {
+	var issued atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/v3/token":
+			issued.Add(1)
+			_, _ = w.Write([]byte(`{"access_token":"stateless","expires_in":900,"token_type":"Bearer"}`))
+		case "/v2/bot/profile/U1":
+			assert.Equal(t, "Bearer stateless", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"userId":"U1"}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	oauth, err := NewClient("", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+
+	src := NewStatelessTokenSource(oauth.OAuth, "1234", "secret").(*reuseTokenSource)
+	now := time.Now()
+	clock := func() time.Time { return now }
+	src.now = clock
+	src.src.(*channelTokenSource).now = clock
+
+	client, err := NewClient("", WithBaseURL(ts.URL), WithTokenSource(src))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, _, err = client.Bot.Profile(context.Background(), "U1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), issued.Load())
+
+	// Refreshed a minute before expiry.
+	now = now.Add(14 * time.Minute)
+	_, _, err = client.Bot.Profile(context.Background(), "U1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), issued.Load())
+}
+
+func TestClientAssertion(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk, err := json.Marshal(map[string]string{
+		"kty": "RSA",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		"d":   base64.RawURLEncoding.EncodeToString(key.D.Bytes()),
+		"p":   base64.RawURLEncoding.EncodeToString(key.Primes[0].Bytes()),
+		"q":   base64.RawURLEncoding.EncodeToString(key.Primes[1].Bytes()),
+	})
+	require.NoError(t, err)
+
+	parsed, err := ParseJWKPrivateKey(jwk)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	now := time.Unix(1700000000, 0)
+	assertion := &ClientAssertion{ChannelID: "1234", KeyID: "kid", PrivateKey: parsed, TokenExpiry: time.Hour}
+	jwt, err := assertion.Sign(now)
+	require.NoError(t, err)
+
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alg":"RS256","typ":"JWT","kid":"kid"}`, string(header))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"iss":"1234","sub":"1234","aud":"https://api.line.me/","exp":1700001800,"token_exp":3600}`, string(payload))
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	_, err = ParseJWKPrivateKey([]byte(`{"kty":"EC"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,295 @@
+package line
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MaxRichMenuBulkUsers is the maximum number of users of a bulk link or
+// unlink request.
+const MaxRichMenuBulkUsers = 500
+
+type RichMenuService struct {
+	client *Client
+}
+
+// Create https://developers.line.biz/en/reference/messaging-api/#create-rich-menu
+func (s *RichMenuService) Create(ctx context.Context, menu *RichMenu, options ...RequestOptionFunc) (*RichMenuIDResponse, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPost, "bot/richmenu", menu, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(RichMenuIDResponse)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// Validate checks a rich menu object without creating it.
+// https://developers.line.biz/en/reference/messaging-api/#validate-rich-menu-object
+func (s *RichMenuService) Validate(ctx context.Context, menu *RichMenu, options ...RequestOptionFunc) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPost, "bot/richmenu/validate", menu, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// Get https://developers.line.biz/en/reference/messaging-api/#get-rich-menu
+func (s *RichMenuService) Get(ctx context.Context, richMenuID string, options ...RequestOptionFunc) (*RichMenu, *Response, error) {
+	u := fmt.Sprintf("bot/richmenu/%s", richMenuID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(RichMenu)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// List https://developers.line.biz/en/reference/messaging-api/#get-rich-menu-list
+func (s *RichMenuService) List(ctx context.Context, options ...RequestOptionFunc) ([]*RichMenu, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, "bot/richmenu/list", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(RichMenuListResponse)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m.RichMenus, resp, nil
+}
+
+// Delete https://developers.line.biz/en/reference/messaging-api/#delete-rich-menu
+func (s *RichMenuService) Delete(ctx context.Context, richMenuID string, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/richmenu/%s", richMenuID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// UploadImage uploads the image of a rich menu. contentType is image/jpeg or
+// image/png; an image can only be uploaded once per rich menu.
+// https://developers.line.biz/en/reference/messaging-api/#upload-rich-menu-image
+func (s *RichMenuService) UploadImage(ctx context.Context, richMenuID, contentType string, image io.Reader, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/richmenu/%s/content", richMenuID)
+	req, err := s.client.NewDataRequest(ctx, http.MethodPost, u, image, options)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return s.client.Do(req, nil)
+}
+
+// DownloadImage streams the image of a rich menu into w.
+// https://developers.line.biz/en/reference/messaging-api/#download-rich-menu-image
+func (s *RichMenuService) DownloadImage(ctx context.Context, richMenuID string, w io.Writer, options ...RequestOptionFunc) (*MessageContent, *Response, error) {
+	u := fmt.Sprintf("bot/richmenu/%s/content", richMenuID)
+	req, err := s.client.NewDataRequest(ctx, http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.client.Do(req, w)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := &MessageContent{
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}
+	return m, resp, nil
+}
+
+// SetDefault https://developers.line.biz/en/reference/messaging-api/#set-default-rich-menu
+func (s *RichMenuService) SetDefault(ctx context.Context, richMenuID string, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/user/all/richmenu/%s", richMenuID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// GetDefault returns the ID of the default rich menu.
+// https://developers.line.biz/en/reference/messaging-api/#get-default-rich-menu-id
+func (s *RichMenuService) GetDefault(ctx context.Context, options ...RequestOptionFunc) (*RichMenuIDResponse, *Response, error) {
+	return s.getID(ctx, "bot/user/all/richmenu", options)
+}
+
+// CancelDefault https://developers.line.biz/en/reference/messaging-api/#clear-default-rich-menu
+func (s *RichMenuService) CancelDefault(ctx context.Context, options ...RequestOptionFunc) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, "bot/user/all/richmenu", nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// LinkUser https://developers.line.biz/en/reference/messaging-api/#link-rich-menu-to-user
+func (s *RichMenuService) LinkUser(ctx context.Context, userID, richMenuID string, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/user/%s/richmenu/%s", userID, richMenuID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// GetUser returns the ID of the rich menu linked to a user.
+// https://developers.line.biz/en/reference/messaging-api/#get-rich-menu-id-of-user
+func (s *RichMenuService) GetUser(ctx context.Context, userID string, options ...RequestOptionFunc) (*RichMenuIDResponse, *Response, error) {
+	return s.getID(ctx, fmt.Sprintf("bot/user/%s/richmenu", userID), options)
+}
+
+// UnlinkUser https://developers.line.biz/en/reference/messaging-api/#unlink-rich-menu-from-user
+func (s *RichMenuService) UnlinkUser(ctx context.Context, userID string, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/user/%s/richmenu", userID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// BulkLink links a rich menu to up to MaxRichMenuBulkUsers users. LINE
+// processes the request asynchronously.
+// https://developers.line.biz/en/reference/messaging-api/#link-rich-menu-to-users
+func (s *RichMenuService) BulkLink(ctx context.Context, opt RichMenuBulkLinkOptions, options ...RequestOptionFunc) (*Response, error) {
+	if err := checkBulkUsers(opt.UserIDs); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, "bot/richmenu/bulk/link", opt, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// BulkUnlink unlinks the rich menus of up to MaxRichMenuBulkUsers users.
+// https://developers.line.biz/en/reference/messaging-api/#unlink-rich-menus-from-users
+func (s *RichMenuService) BulkUnlink(ctx context.Context, opt RichMenuBulkUnlinkOptions, options ...RequestOptionFunc) (*Response, error) {
+	if err := checkBulkUsers(opt.UserIDs); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, "bot/richmenu/bulk/unlink", opt, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// CreateAlias https://developers.line.biz/en/reference/messaging-api/#create-rich-menu-alias
+func (s *RichMenuService) CreateAlias(ctx context.Context, alias RichMenuAlias, options ...RequestOptionFunc) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPost, "bot/richmenu/alias", alias, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// UpdateAlias points an alias to another rich menu.
+// https://developers.line.biz/en/reference/messaging-api/#update-rich-menu-alias
+func (s *RichMenuService) UpdateAlias(ctx context.Context, aliasID string, opt RichMenuAliasUpdateOptions, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/richmenu/alias/%s", aliasID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, opt, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// DeleteAlias https://developers.line.biz/en/reference/messaging-api/#delete-rich-menu-alias
+func (s *RichMenuService) DeleteAlias(ctx context.Context, aliasID string, options ...RequestOptionFunc) (*Response, error) {
+	u := fmt.Sprintf("bot/richmenu/alias/%s", aliasID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// GetAlias https://developers.line.biz/en/reference/messaging-api/#get-rich-menu-alias-by-id
+func (s *RichMenuService) GetAlias(ctx context.Context, aliasID string, options ...RequestOptionFunc) (*RichMenuAlias, *Response, error) {
+	u := fmt.Sprintf("bot/richmenu/alias/%s", aliasID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(RichMenuAlias)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+// ListAliases https://developers.line.biz/en/reference/messaging-api/#get-rich-menu-alias-list
+func (s *RichMenuService) ListAliases(ctx context.Context, options ...RequestOptionFunc) ([]*RichMenuAlias, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, "bot/richmenu/alias/list", nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(RichMenuAliasListResponse)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m.Aliases, resp, nil
+}
+
+func (s *RichMenuService) getID(ctx context.Context, u string, options []RequestOptionFunc) (*RichMenuIDResponse, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := new(RichMenuIDResponse)
+	resp, err := s.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, resp, nil
+}
+
+func checkBulkUsers(userIDs []string) error {
+	var v validator
+	v.count("userIds", len(userIDs), 1, MaxRichMenuBulkUsers)
+	return v.err()
+}
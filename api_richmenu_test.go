@@ -0,0 +1,131 @@
+package line
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RichMenu(t *testing.T) {
+	menu := &RichMenu{
+		Size:        RichMenuSize{Width: 2500, Height: 843},
+		Selected:    true,
+		Name:        "main",
+		ChatBarText: "Menu",
+		Areas: []RichMenuArea{
+			{Bounds: RichMenuBounds{Width: 1250, Height: 843}, Action: NewPostbackAction("Buy", "action=buy")},
+			{Bounds: RichMenuBounds{X: 1250, Width: 1250, Height: 843}, Action: NewRichMenuSwitchAction("Next", "page-2", "page=2")},
+		},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v2/bot/richmenu":
+			var got RichMenu
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&got)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, *menu, got)
+			_, _ = w.Write([]byte(`{"richMenuId":"richmenu-1"}`))
+		case "GET /v2/bot/richmenu/list":
+			_ = json.NewEncoder(w).Encode(map[string]any{"richmenus": []*RichMenu{menu}})
+		case "POST /v2/bot/user/all/richmenu/richmenu-1",
+			"POST /v2/bot/user/U1/richmenu/richmenu-1",
+			"DELETE /v2/bot/richmenu/alias/page-1":
+		case "GET /v2/bot/user/all/richmenu":
+			_, _ = w.Write([]byte(`{"richMenuId":"richmenu-1"}`))
+		case "POST /v2/bot/richmenu/bulk/link":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"richMenuId":"richmenu-1","userIds":["U1","U2"]}`, string(body))
+			w.WriteHeader(http.StatusAccepted)
+		case "POST /v2/bot/richmenu/alias":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"richMenuAliasId":"page-1","richMenuId":"richmenu-1"}`, string(body))
+		case "GET /v2/bot/richmenu/alias/list":
+			_, _ = w.Write([]byte(`{"aliases":[{"richMenuAliasId":"page-1","richMenuId":"richmenu-1"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	created, _, err := client.RichMenu.Create(ctx, menu)
+	require.NoError(t, err)
+	assert.Equal(t, "richmenu-1", created.RichMenuID)
+
+	menus, _, err := client.RichMenu.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, menus, 1)
+	assert.Equal(t, menu, menus[0])
+
+	_, err = client.RichMenu.SetDefault(ctx, "richmenu-1")
+	require.NoError(t, err)
+	def, _, err := client.RichMenu.GetDefault(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "richmenu-1", def.RichMenuID)
+
+	_, err = client.RichMenu.LinkUser(ctx, "U1", "richmenu-1")
+	require.NoError(t, err)
+	_, err = client.RichMenu.BulkLink(ctx, RichMenuBulkLinkOptions{RichMenuID: "richmenu-1", UserIDs: []string{"U1", "U2"}})
+	require.NoError(t, err)
+
+	_, err = client.RichMenu.CreateAlias(ctx, RichMenuAlias{RichMenuAliasID: "page-1", RichMenuID: "richmenu-1"})
+	require.NoError(t, err)
+	aliases, _, err := client.RichMenu.ListAliases(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*RichMenuAlias{{RichMenuAliasID: "page-1", RichMenuID: "richmenu-1"}}, aliases)
+	_, err = client.RichMenu.DeleteAlias(ctx, "page-1")
+	require.NoError(t, err)
+
+	_, err = client.RichMenu.BulkUnlink(ctx, RichMenuBulkUnlinkOptions{UserIDs: make([]string, MaxRichMenuBulkUsers+1)})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "userIds", verr.Details[0].Property)
+}
+
+func Test_RichMenuImage(t *testing.T) {
+	image := []byte("\x89PNG\r\n\x1a\n")
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to api host: %s", r.URL.Path)
+	}))
+	defer api.Close()
+
+	data := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/bot/richmenu/richmenu-1/content", r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, image, body)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(image)
+		}
+	}))
+	defer data.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(api.URL), WithDataBaseURL(data.URL))
+	require.NoError(t, err)
+
+	_, err = client.RichMenu.UploadImage(context.Background(), "richmenu-1", "image/png", bytes.NewReader(image))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	content, _, err := client.RichMenu.DownloadImage(context.Background(), "richmenu-1", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", content.ContentType)
+	assert.Equal(t, image, buf.Bytes())
+}
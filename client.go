@@ -16,9 +16,10 @@ import (
 )
 
 const (
-	defaultBaseURL = "https://api.line.me/"
-	apiVersionPath = "v2/"
-	userAgent      = "go-line"
+	defaultBaseURL     = "https://api.line.me/"
+	defaultDataBaseURL = "https://api-data.line.me/"
+	apiVersionPath     = "v2/"
+	userAgent          = "go-line"
 
 	contentType = "application/json"
 )
@@ -35,17 +36,35 @@ type Client struct {
 	client                *http.Client
 	authType              AuthType
 	baseURL               *url.URL
+	dataBaseURL           *url.URL
 	apiVersionPath        string
 	defaultRequestOptions []RequestOptionFunc
+	rateLimiter           *RateLimiter
 	token                 string
+	tokenSource           TokenSource
 	UserAgent             string
 	ContentType           string
 	Bot                   *BotService
 	Message               *MessageService
+	OAuth                 *OAuthService
+	RichMenu              *RichMenuService
+	Module                *ModuleService
 }
 
 type Response struct {
 	*http.Response
+
+	// AcceptedRequestID is set when LINE answered 409 Conflict because a
+	// request with the same X-Line-Retry-Key was already accepted. It holds
+	// the request ID of that earlier request.
+	AcceptedRequestID string
+}
+
+// RetryKeyAccepted reports whether the request was a duplicate of an already
+// accepted request carrying the same retry key. The messages have been sent
+// once, so this is not an error.
+func (r *Response) RetryKeyAccepted() bool {
+	return r.AcceptedRequestID != ""
 }
 
 func NewClient(token string, options ...ClientOptionFunc) (*Client, error) {
@@ -63,7 +82,7 @@ func NewClient(token string, options ...ClientOptionFunc) (*Client, error) {
 
 func newClient(options ...ClientOptionFunc) (*Client, error) {
 	c := &Client{
-		client:      NewRetryableHTTPClient(),
+		client:      NewRetryableHTTPClient(WithLineRetryPolicy()),
 		ContentType: contentType,
 	}
 
@@ -72,6 +91,11 @@ func newClient(options ...ClientOptionFunc) (*Client, error) {
 		return nil, err
 	}
 
+	err = c.setDataBaseURL(defaultDataBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, fn := range options {
 		if fn == nil {
 			continue
@@ -81,18 +105,20 @@ func newClient(options ...ClientOptionFunc) (*Client, error) {
 		}
 	}
 
+	if c.tokenSource == nil {
+		c.tokenSource = StaticTokenSource(c.token)
+	}
+
 	c.Bot = &BotService{client: c}
 	c.Message = &MessageService{client: c}
+	c.OAuth = &OAuthService{client: c}
+	c.RichMenu = &RichMenuService{client: c}
+	c.Module = &ModuleService{client: c}
 	return c, nil
 }
 
 func (c *Client) setBaseURL(urlStr string) error {
-	// Make sure the given URL end with a slash
-	if !strings.HasSuffix(urlStr, "/") {
-		urlStr += "/"
-	}
-
-	baseURL, err := url.Parse(urlStr)
+	baseURL, err := parseBaseURL(urlStr)
 	if err != nil {
 		return err
 	}
@@ -103,17 +129,51 @@ func (c *Client) setBaseURL(urlStr string) error {
 	return nil
 }
 
+func (c *Client) setDataBaseURL(urlStr string) error {
+	dataBaseURL, err := parseBaseURL(urlStr)
+	if err != nil {
+		return err
+	}
+
+	// Update the data base URL of the client.
+	c.dataBaseURL = dataBaseURL
+
+	return nil
+}
+
+func parseBaseURL(urlStr string) (*url.URL, error) {
+	// Make sure the given URL end with a slash
+	if !strings.HasSuffix(urlStr, "/") {
+		urlStr += "/"
+	}
+	return url.Parse(urlStr)
+}
+
+// NewRequest creates a request against the API host (api.line.me).
 func (c *Client) NewRequest(ctx context.Context, method, path string, opt interface{}, options []RequestOptionFunc) (*http.Request, error) {
-	u := *c.baseURL
+	return c.newRequest(ctx, c.baseURL, c.apiVersionPath, method, path, opt, options)
+}
+
+// NewDataRequest creates a request against the data host (api-data.line.me),
+// which serves message contents and rich menu images.
+func (c *Client) NewDataRequest(ctx context.Context, method, path string, opt interface{}, options []RequestOptionFunc) (*http.Request, error) {
+	return c.newRequest(ctx, c.dataBaseURL, c.apiVersionPath, method, path, opt, options)
+}
+
+// newRequest creates a request for base + versionPath + path. POST, PATCH and
+// PUT requests send opt as JSON, as a form if opt is url.Values, or as is if
+// opt is an io.Reader; other requests encode opt into the query string.
+func (c *Client) newRequest(ctx context.Context, base *url.URL, versionPath, method, path string, opt interface{}, options []RequestOptionFunc) (*http.Request, error) {
+	u := *base
 	unescaped, err := url.PathUnescape(path)
 	if err != nil {
 		return nil, err
 	}
 
 	// Set the encoded path data
-	baseURL := c.baseURL.Path
-	if !strings.HasSuffix(baseURL, c.apiVersionPath) {
-		baseURL += c.apiVersionPath
+	baseURL := base.Path
+	if !strings.HasSuffix(baseURL, versionPath) {
+		baseURL += versionPath
 	}
 	u.RawPath = baseURL + path
 	u.Path = baseURL + unescaped
@@ -122,7 +182,6 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, opt interf
 	reqHeaders := make(http.Header)
 	reqHeaders.Set("Content-Type", c.ContentType)
 	reqHeaders.Set("Accept", "application/json")
-	reqHeaders.Set("Authorization", c.token)
 
 	if c.UserAgent != "" {
 		reqHeaders.Set("User-Agent", c.UserAgent)
@@ -132,7 +191,13 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, opt interf
 	var body io.Reader
 	switch {
 	case method == http.MethodPatch || method == http.MethodPost || method == http.MethodPut:
-		if opt != nil {
+		if values, ok := opt.(url.Values); ok {
+			body = strings.NewReader(values.Encode())
+			reqHeaders.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else if r, ok := opt.(io.Reader); ok {
+			// Binary uploads, the caller sets the Content-Type.
+			body = r
+		} else if opt != nil {
 			jsonData, err := json.Marshal(opt)
 			if err != nil {
 				return nil, err
@@ -169,20 +234,49 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, opt interf
 	return req, nil
 }
 
+type withoutAuthKey struct{}
+
+// withoutAuth marks req to be sent without an Authorization header, for the
+// OAuth endpoints that issue the token in the first place.
+func withoutAuth(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), withoutAuthKey{}, true))
+}
+
+func isWithoutAuth(req *http.Request) bool {
+	v, _ := req.Context().Value(withoutAuthKey{}).(bool)
+	return v
+}
+
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	// Set the correct authentication header. If using basic auth, then check
-	// if we already have a token and if not first authenticate and get one.
-	var basicAuthToken string
-	switch c.authType {
-	case BasicAuth:
-		basicAuthToken = c.token
-		req.Header.Set("Authorization", "Bearer "+basicAuthToken)
-	}
-	resp, err := c.client.Do(req)
+	// Set the correct authentication header. The token source is consulted
+	// per request, so a refreshed token is picked up without a new client.
+	switch {
+	case isWithoutAuth(req):
+		req.Header.Del("Authorization")
+	case c.authType == BasicAuth:
+		token, err := c.tokenSource.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	}
+	var endpoint string
+	if c.rateLimiter != nil {
+		endpoint = c.endpointPath(req.URL)
+		if err := c.rateLimiter.Wait(req.Context(), endpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.Do(withRetryInfo(req))
 	if err != nil {
 		return nil, err
 	}
 
+	if c.rateLimiter != nil {
+		c.rateLimiter.handleResponse(endpoint, resp)
+	}
+
 	defer resp.Body.Close()
 	defer func() {
 		_, _ = io.Copy(io.Discard, resp.Body)
@@ -190,6 +284,17 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 
 	response := newResponse(resp)
 
+	if response.RetryKeyAccepted() {
+		// The body of an accepted retry has the same shape as a successful
+		// response, e.g. the sentMessages of a push.
+		if v != nil {
+			if _, ok := v.(io.Writer); !ok {
+				_ = json.NewDecoder(resp.Body).Decode(v)
+			}
+		}
+		return response, nil
+	}
+
 	err = CheckResponse(resp)
 	if err != nil {
 		// Even though there was an error, we still return the response
@@ -208,47 +313,99 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	return response, err
 }
 
+// endpointPath returns the path of u relative to the API version of the API
+// or data host, e.g. "bot/message/push".
+func (c *Client) endpointPath(u *url.URL) string {
+	for _, base := range []*url.URL{c.baseURL, c.dataBaseURL} {
+		prefix := base.Path
+		if !strings.HasSuffix(prefix, c.apiVersionPath) {
+			prefix += c.apiVersionPath
+		}
+		if u.Host == base.Host && strings.HasPrefix(u.Path, prefix) {
+			return strings.TrimPrefix(u.Path, prefix)
+		}
+	}
+	return strings.TrimPrefix(u.Path, "/")
+}
+
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
+	if r.StatusCode == http.StatusConflict {
+		response.AcceptedRequestID = r.Header.Get("X-Line-Accepted-Request-Id")
+	}
 	// response.populatePageValues()
 	// response.populateLinkValues()
 	return response
 }
 
+// ErrorResponse is returned for every unsuccessful API response. Message and
+// Details are taken from the LINE error body; when the body does not follow
+// the LINE format, Message holds a flattened form of it.
 type ErrorResponse struct {
 	Body     []byte
 	Response *http.Response
-	Message  string
+
+	Message           string
+	Details           []ErrorDetail
+	RequestID         string
+	AcceptedRequestID string
 }
 
 func (e *ErrorResponse) Error() string {
 	path, _ := url.QueryUnescape(e.Response.Request.URL.Path)
 	errorURL := fmt.Sprintf("%s://%s%s", e.Response.Request.URL.Scheme, e.Response.Request.URL.Host, path)
 
-	if e.Message == "" {
-		return fmt.Sprintf("%s %s: %d", e.Response.Request.Method, errorURL, e.Response.StatusCode)
+	msg := fmt.Sprintf("%s %s: %d", e.Response.Request.Method, errorURL, e.Response.StatusCode)
+	if e.Message != "" {
+		msg += " " + e.Message
 	}
-	return fmt.Sprintf("%s %s: %d %s", e.Response.Request.Method, errorURL, e.Response.StatusCode, e.Message)
+	if len(e.Details) > 0 {
+		details := make([]string, len(e.Details))
+		for i, d := range e.Details {
+			details[i] = fmt.Sprintf("{%s: %s}", d.Property, d.Message)
+		}
+		msg += fmt.Sprintf(" [%s]", strings.Join(details, ", "))
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id: %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Is reports a 404 response as ErrNotFound, so that errors.Is keeps working
+// for callers checking the sentinel error.
+func (e *ErrorResponse) Is(target error) bool {
+	return target == ErrNotFound && e.Response.StatusCode == http.StatusNotFound
 }
 
 func CheckResponse(r *http.Response) error {
 	switch r.StatusCode {
 	case 200, 201, 202, 204, 304:
 		return nil
-	case 404:
-		return ErrNotFound
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{
+		Response:          r,
+		RequestID:         r.Header.Get("X-Line-Request-Id"),
+		AcceptedRequestID: r.Header.Get("X-Line-Accepted-Request-Id"),
+	}
 
 	data, err := io.ReadAll(r.Body)
 	if err == nil && strings.TrimSpace(string(data)) != "" {
 		errorResponse.Body = data
 
+		var lineErr struct {
+			Message string        `json:"message"`
+			Details []ErrorDetail `json:"details"`
+		}
 		var raw interface{}
-		if err := json.Unmarshal(data, &raw); err != nil {
+		switch {
+		case json.Unmarshal(data, &lineErr) == nil && lineErr.Message != "":
+			errorResponse.Message = lineErr.Message
+			errorResponse.Details = lineErr.Details
+		case json.Unmarshal(data, &raw) != nil:
 			errorResponse.Message = fmt.Sprintf("failed to parse unknown error format: %s", data)
-		} else {
+		default:
 			errorResponse.Message = parseError(raw)
 		}
 	}
@@ -256,6 +413,39 @@ func CheckResponse(r *http.Response) error {
 	return errorResponse
 }
 
+// IsRateLimited reports whether err is a 429 response caused by exceeding
+// the API rate limit.
+func IsRateLimited(err error) bool {
+	e, ok := asErrorResponse(err)
+	return ok && e.Response.StatusCode == http.StatusTooManyRequests && !isQuotaMessage(e.Message)
+}
+
+// IsQuotaExceeded reports whether err is a 429 response caused by reaching
+// the monthly message limit.
+func IsQuotaExceeded(err error) bool {
+	e, ok := asErrorResponse(err)
+	return ok && e.Response.StatusCode == http.StatusTooManyRequests && isQuotaMessage(e.Message)
+}
+
+// IsInvalidToken reports whether err is a 401 response, i.e. the channel
+// access token is invalid, expired or revoked.
+func IsInvalidToken(err error) bool {
+	e, ok := asErrorResponse(err)
+	return ok && e.Response.StatusCode == http.StatusUnauthorized
+}
+
+func asErrorResponse(err error) (*ErrorResponse, bool) {
+	var e *ErrorResponse
+	if !errors.As(err, &e) || e.Response == nil {
+		return nil, false
+	}
+	return e, true
+}
+
+func isQuotaMessage(message string) bool {
+	return strings.Contains(strings.ToLower(message), "monthly limit")
+}
+
 func parseError(raw interface{}) string {
 	switch raw := raw.(type) {
 	case string:
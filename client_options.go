@@ -15,7 +15,16 @@ func WithBaseURL(urlStr string) ClientOptionFunc {
 	}
 }
 
-// WithToken sets the token for API requests to a custom endpoint.
+// WithDataBaseURL sets the base URL for API requests served by the data
+// host, such as message content downloads.
+func WithDataBaseURL(urlStr string) ClientOptionFunc {
+	return func(c *Client) error {
+		return c.setDataBaseURL(urlStr)
+	}
+}
+
+// WithToken sets a static token for API requests. It is ignored if
+// WithTokenSource is used.
 func WithToken(token string) ClientOptionFunc {
 	return func(c *Client) error {
 		c.token = token
@@ -43,3 +52,22 @@ func WithApiVersionPath(apiVersionPath string) ClientOptionFunc {
 		return nil
 	}
 }
+
+// WithRateLimiter makes the client wait for the limiter before sending each
+// request and pause an endpoint when LINE answers 429 with Retry-After.
+func WithRateLimiter(limiter *RateLimiter) ClientOptionFunc {
+	return func(c *Client) error {
+		c.rateLimiter = limiter
+		return nil
+	}
+}
+
+// WithTokenSource makes the client take the bearer token of each request
+// from src instead of the token passed to NewClient. The source must be safe
+// for concurrent use; wrap it with ReuseTokenSource to cache its tokens.
+func WithTokenSource(src TokenSource) ClientOptionFunc {
+	return func(c *Client) error {
+		c.tokenSource = src
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Line-Request-Id", "req-"+r.URL.Path[len("/v2/bot/profile/"):])
+		switch r.URL.Path {
+		case "/v2/bot/profile/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"The request body has 1 error(s)","details":[{"message":"May not be empty","property":"messages[0].text"}]}`))
+		case "/v2/bot/profile/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		case "/v2/bot/profile/token":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Authentication failed. Confirm that the access token in the authorization header is valid."}`))
+		case "/v2/bot/profile/rate":
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"The API rate limit has been exceeded. Try again later."}`))
+		case "/v2/bot/profile/quota":
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"You have reached your monthly limit."}`))
+		case "/v2/bot/profile/unknown":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_request","error_description":"some error"}`))
+		}
+	}))
+	defer ts.Close()
+
+	httpClient := NewRetryableHTTPClient(WithRetryableHTTPClientRetryMax(0))
+	client, err := NewClient("test-token", WithBaseURL(ts.URL), WithClient(httpClient))
+	require.NoError(t, err)
+
+	profile := func(id string) error {
+		_, _, err := client.Bot.Profile(context.Background(), id)
+		return err
+	}
+
+	err = profile("bad")
+	var e *ErrorResponse
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, "The request body has 1 error(s)", e.Message)
+	assert.Equal(t, []ErrorDetail{{Message: "May not be empty", Property: "messages[0].text"}}, e.Details)
+	assert.Equal(t, "req-bad", e.RequestID)
+	assert.Contains(t, err.Error(), "400 The request body has 1 error(s) [{messages[0].text: May not be empty}] (request id: req-bad)")
+
+	err = profile("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, "req-missing", e.RequestID)
+
+	assert.True(t, IsInvalidToken(profile("token")))
+
+	err = profile("rate")
+	assert.True(t, IsRateLimited(err))
+	assert.False(t, IsQuotaExceeded(err))
+
+	err = profile("quota")
+	assert.False(t, IsRateLimited(err))
+	assert.True(t, IsQuotaExceeded(err))
+
+	err = profile("unknown")
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, "{error: invalid_request}, {error_description: some error}", e.Message)
+	assert.False(t, errors.Is(err, ErrNotFound))
+}
@@ -0,0 +1,411 @@
+package line
+
+// The constructors below build flex containers and components with their
+// type already set. Each component has chainable With* setters for its most
+// common properties; any other field can still be set on the returned struct.
+//
+//	msg := NewFlexMessage("Menu", NewFlexBubble().
+//		WithHero(NewFlexImage("https://example.com/cafe.jpg").WithSize("full")).
+//		WithBody(NewFlexBox(FlexBoxLayoutVertical,
+//			NewFlexText("Brown Cafe").WithWeight(FlexTextWeightBold).WithSize("xl"),
+//		)))
+
+// NewFlexMessage returns a flex message with the given alternative text.
+func NewFlexMessage(altText string, contents FlexContainer) *FlexMessage {
+	return &FlexMessage{Type: FlexMessageType, AltText: altText, Contents: contents}
+}
+
+// NewFlexBubble returns an empty bubble container.
+func NewFlexBubble() *BubbleContainer {
+	return &BubbleContainer{Type: BubbleFlexContainerType}
+}
+
+func (c *BubbleContainer) WithSize(size FlexBubbleSize) *BubbleContainer {
+	c.Size = size
+	return c
+}
+
+func (c *BubbleContainer) WithDirection(direction FlexBubbleDirection) *BubbleContainer {
+	c.Direction = direction
+	return c
+}
+
+func (c *BubbleContainer) WithHeader(header *BoxComponent) *BubbleContainer {
+	c.Header = header
+	return c
+}
+
+// WithHero sets the hero block, which must be a *BoxComponent, *ImageComponent
+// or *VideoComponent.
+func (c *BubbleContainer) WithHero(hero FlexComponent) *BubbleContainer {
+	c.Hero = hero
+	return c
+}
+
+func (c *BubbleContainer) WithBody(body *BoxComponent) *BubbleContainer {
+	c.Body = body
+	return c
+}
+
+func (c *BubbleContainer) WithFooter(footer *BoxComponent) *BubbleContainer {
+	c.Footer = footer
+	return c
+}
+
+func (c *BubbleContainer) WithStyles(styles *BubbleStyle) *BubbleContainer {
+	c.Styles = styles
+	return c
+}
+
+func (c *BubbleContainer) WithAction(action Action) *BubbleContainer {
+	c.Action = action
+	return c
+}
+
+// NewFlexCarousel returns a carousel container holding the given bubbles.
+func NewFlexCarousel(bubbles ...*BubbleContainer) *CarouselContainer {
+	return &CarouselContainer{Type: CarouselFlexContainerType, Contents: bubbles}
+}
+
+// Append adds bubbles to the carousel.
+func (c *CarouselContainer) Append(bubbles ...*BubbleContainer) *CarouselContainer {
+	c.Contents = append(c.Contents, bubbles...)
+	return c
+}
+
+// NewFlexBox returns a box component with the given layout and children.
+func NewFlexBox(layout FlexBoxLayout, contents ...FlexComponent) *BoxComponent {
+	if contents == nil {
+		contents = []FlexComponent{}
+	}
+	return &BoxComponent{Type: BoxFlexComponentType, Layout: layout, Contents: contents}
+}
+
+// Append adds children to the box.
+func (c *BoxComponent) Append(contents ...FlexComponent) *BoxComponent {
+	c.Contents = append(c.Contents, contents...)
+	return c
+}
+
+func (c *BoxComponent) WithFlex(flex int) *BoxComponent {
+	c.Flex = &flex
+	return c
+}
+
+func (c *BoxComponent) WithSpacing(spacing string) *BoxComponent {
+	c.Spacing = spacing
+	return c
+}
+
+func (c *BoxComponent) WithMargin(margin string) *BoxComponent {
+	c.Margin = margin
+	return c
+}
+
+func (c *BoxComponent) WithPaddingAll(padding string) *BoxComponent {
+	c.PaddingAll = padding
+	return c
+}
+
+func (c *BoxComponent) WithBackgroundColor(color string) *BoxComponent {
+	c.BackgroundColor = color
+	return c
+}
+
+func (c *BoxComponent) WithBorder(width, color string) *BoxComponent {
+	c.BorderWidth = width
+	c.BorderColor = color
+	return c
+}
+
+func (c *BoxComponent) WithCornerRadius(radius string) *BoxComponent {
+	c.CornerRadius = radius
+	return c
+}
+
+func (c *BoxComponent) WithWidth(width string) *BoxComponent {
+	c.Width = width
+	return c
+}
+
+func (c *BoxComponent) WithHeight(height string) *BoxComponent {
+	c.Height = height
+	return c
+}
+
+func (c *BoxComponent) WithPosition(position FlexPosition) *BoxComponent {
+	c.Position = position
+	return c
+}
+
+func (c *BoxComponent) WithJustifyContent(justifyContent FlexJustifyContent) *BoxComponent {
+	c.JustifyContent = justifyContent
+	return c
+}
+
+func (c *BoxComponent) WithAlignItems(alignItems FlexAlignItems) *BoxComponent {
+	c.AlignItems = alignItems
+	return c
+}
+
+func (c *BoxComponent) WithBackground(background *BoxBackground) *BoxComponent {
+	c.Background = background
+	return c
+}
+
+func (c *BoxComponent) WithAction(action Action) *BoxComponent {
+	c.Action = action
+	return c
+}
+
+// NewFlexButton returns a button component performing the given action.
+func NewFlexButton(action Action) *ButtonComponent {
+	return &ButtonComponent{Type: ButtonFlexComponentType, Action: action}
+}
+
+func (c *ButtonComponent) WithFlex(flex int) *ButtonComponent {
+	c.Flex = &flex
+	return c
+}
+
+func (c *ButtonComponent) WithMargin(margin string) *ButtonComponent {
+	c.Margin = margin
+	return c
+}
+
+func (c *ButtonComponent) WithHeight(height FlexButtonHeight) *ButtonComponent {
+	c.Height = height
+	return c
+}
+
+func (c *ButtonComponent) WithStyle(style FlexButtonStyle) *ButtonComponent {
+	c.Style = style
+	return c
+}
+
+func (c *ButtonComponent) WithColor(color string) *ButtonComponent {
+	c.Color = color
+	return c
+}
+
+func (c *ButtonComponent) WithGravity(gravity FlexGravity) *ButtonComponent {
+	c.Gravity = gravity
+	return c
+}
+
+// NewFlexImage returns an image component showing the given HTTPS URL.
+func NewFlexImage(url string) *ImageComponent {
+	return &ImageComponent{Type: ImageFlexComponentType, URL: url}
+}
+
+func (c *ImageComponent) WithFlex(flex int) *ImageComponent {
+	c.Flex = &flex
+	return c
+}
+
+func (c *ImageComponent) WithMargin(margin string) *ImageComponent {
+	c.Margin = margin
+	return c
+}
+
+func (c *ImageComponent) WithAlign(align FlexAlign) *ImageComponent {
+	c.Align = align
+	return c
+}
+
+func (c *ImageComponent) WithGravity(gravity FlexGravity) *ImageComponent {
+	c.Gravity = gravity
+	return c
+}
+
+func (c *ImageComponent) WithSize(size string) *ImageComponent {
+	c.Size = size
+	return c
+}
+
+func (c *ImageComponent) WithAspectRatio(aspectRatio string) *ImageComponent {
+	c.AspectRatio = aspectRatio
+	return c
+}
+
+func (c *ImageComponent) WithAspectMode(aspectMode FlexImageAspectMode) *ImageComponent {
+	c.AspectMode = aspectMode
+	return c
+}
+
+func (c *ImageComponent) WithBackgroundColor(color string) *ImageComponent {
+	c.BackgroundColor = color
+	return c
+}
+
+func (c *ImageComponent) WithAction(action Action) *ImageComponent {
+	c.Action = action
+	return c
+}
+
+func (c *ImageComponent) WithAnimated(animated bool) *ImageComponent {
+	c.Animated = animated
+	return c
+}
+
+// NewFlexIcon returns an icon component for baseline boxes.
+func NewFlexIcon(url string) *IconComponent {
+	return &IconComponent{Type: IconFlexComponentType, URL: url}
+}
+
+func (c *IconComponent) WithMargin(margin string) *IconComponent {
+	c.Margin = margin
+	return c
+}
+
+func (c *IconComponent) WithSize(size string) *IconComponent {
+	c.Size = size
+	return c
+}
+
+func (c *IconComponent) WithAspectRatio(aspectRatio string) *IconComponent {
+	c.AspectRatio = aspectRatio
+	return c
+}
+
+// NewFlexText returns a text component with the given text.
+func NewFlexText(text string) *TextComponent {
+	return &TextComponent{Type: TextFlexComponentType, Text: text}
+}
+
+// WithContents sets spans that replace the text of the component.
+func (c *TextComponent) WithContents(spans ...*SpanComponent) *TextComponent {
+	c.Contents = spans
+	return c
+}
+
+func (c *TextComponent) WithFlex(flex int) *TextComponent {
+	c.Flex = &flex
+	return c
+}
+
+func (c *TextComponent) WithMargin(margin string) *TextComponent {
+	c.Margin = margin
+	return c
+}
+
+func (c *TextComponent) WithSize(size string) *TextComponent {
+	c.Size = size
+	return c
+}
+
+func (c *TextComponent) WithAlign(align FlexAlign) *TextComponent {
+	c.Align = align
+	return c
+}
+
+func (c *TextComponent) WithGravity(gravity FlexGravity) *TextComponent {
+	c.Gravity = gravity
+	return c
+}
+
+func (c *TextComponent) WithWrap(wrap bool) *TextComponent {
+	c.Wrap = wrap
+	return c
+}
+
+func (c *TextComponent) WithMaxLines(maxLines int) *TextComponent {
+	c.MaxLines = maxLines
+	return c
+}
+
+func (c *TextComponent) WithWeight(weight FlexTextWeight) *TextComponent {
+	c.Weight = weight
+	return c
+}
+
+func (c *TextComponent) WithColor(color string) *TextComponent {
+	c.Color = color
+	return c
+}
+
+func (c *TextComponent) WithStyle(style FlexTextStyle) *TextComponent {
+	c.Style = style
+	return c
+}
+
+func (c *TextComponent) WithDecoration(decoration FlexTextDecoration) *TextComponent {
+	c.Decoration = decoration
+	return c
+}
+
+func (c *TextComponent) WithAction(action Action) *TextComponent {
+	c.Action = action
+	return c
+}
+
+// NewFlexSpan returns a span to be used in TextComponent.WithContents.
+func NewFlexSpan(text string) *SpanComponent {
+	return &SpanComponent{Type: SpanFlexComponentType, Text: text}
+}
+
+func (c *SpanComponent) WithSize(size string) *SpanComponent {
+	c.Size = size
+	return c
+}
+
+func (c *SpanComponent) WithColor(color string) *SpanComponent {
+	c.Color = color
+	return c
+}
+
+func (c *SpanComponent) WithWeight(weight FlexTextWeight) *SpanComponent {
+	c.Weight = weight
+	return c
+}
+
+func (c *SpanComponent) WithStyle(style FlexTextStyle) *SpanComponent {
+	c.Style = style
+	return c
+}
+
+func (c *SpanComponent) WithDecoration(decoration FlexTextDecoration) *SpanComponent {
+	c.Decoration = decoration
+	return c
+}
+
+// NewFlexSeparator returns a separator component.
+func NewFlexSeparator() *SeparatorComponent {
+	return &SeparatorComponent{Type: SeparatorFlexComponentType}
+}
+
+func (c *SeparatorComponent) WithMargin(margin string) *SeparatorComponent {
+	c.Margin = margin
+	return c
+}
+
+func (c *SeparatorComponent) WithColor(color string) *SeparatorComponent {
+	c.Color = color
+	return c
+}
+
+// NewFlexFiller returns a filler component.
+func NewFlexFiller() *FillerComponent {
+	return &FillerComponent{Type: FillerFlexComponentType}
+}
+
+func (c *FillerComponent) WithFlex(flex int) *FillerComponent {
+	c.Flex = &flex
+	return c
+}
+
+// NewFlexVideo returns a video component for the hero block. altContent is
+// shown on clients that cannot play the video.
+func NewFlexVideo(url, previewURL string, altContent FlexComponent) *VideoComponent {
+	return &VideoComponent{Type: VideoFlexComponentType, URL: url, PreviewURL: previewURL, AltContent: altContent}
+}
+
+func (c *VideoComponent) WithAspectRatio(aspectRatio string) *VideoComponent {
+	c.AspectRatio = aspectRatio
+	return c
+}
+
+func (c *VideoComponent) WithAction(action Action) *VideoComponent {
+	c.Action = action
+	return c
+}
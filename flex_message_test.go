@@ -0,0 +1,78 @@
+package line
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlexMessageBuilder(t *testing.T) {
+	expected := `{
+		"type": "flex",
+		"altText": "Brown Cafe",
+		"contents": {
+			"type": "carousel",
+			"contents": [
+				{
+					"type": "bubble",
+					"size": "kilo",
+					"hero": {"type": "image", "url": "https://example.com/cafe.jpg", "size": "full", "aspectRatio": "20:13", "aspectMode": "cover"},
+					"body": {
+						"type": "box",
+						"layout": "vertical",
+						"spacing": "sm",
+						"contents": [
+							{"type": "text", "text": "Brown Cafe", "size": "xl", "weight": "bold"},
+							{
+								"type": "box",
+								"layout": "baseline",
+								"contents": [
+									{"type": "icon", "url": "https://example.com/star.png", "size": "sm"},
+									{"type": "text", "contents": [{"type": "span", "text": "4.0", "color": "#999999"}], "flex": 0, "margin": "md"}
+								]
+							},
+							{"type": "separator", "margin": "md"},
+							{"type": "filler"}
+						]
+					},
+					"footer": {
+						"type": "box",
+						"layout": "vertical",
+						"contents": [
+							{"type": "button", "action": {"type": "uri", "label": "Call", "uri": "https://example.com"}, "height": "sm", "style": "link"}
+						]
+					}
+				}
+			]
+		}
+	}`
+
+	msg := NewFlexMessage("Brown Cafe", NewFlexCarousel(
+		NewFlexBubble().
+			WithSize(FlexBubbleSizeKilo).
+			WithHero(NewFlexImage("https://example.com/cafe.jpg").
+				WithSize("full").
+				WithAspectRatio("20:13").
+				WithAspectMode(FlexImageAspectModeCover)).
+			WithBody(NewFlexBox(FlexBoxLayoutVertical,
+				NewFlexText("Brown Cafe").WithWeight(FlexTextWeightBold).WithSize("xl"),
+				NewFlexBox(FlexBoxLayoutBaseline,
+					NewFlexIcon("https://example.com/star.png").WithSize("sm"),
+					NewFlexText("").WithContents(NewFlexSpan("4.0").WithColor("#999999")).WithFlex(0).WithMargin("md"),
+				),
+				NewFlexSeparator().WithMargin("md"),
+				NewFlexFiller(),
+			).WithSpacing("sm")).
+			WithFooter(NewFlexBox(FlexBoxLayoutVertical,
+				NewFlexButton(NewURIAction("Call", "https://example.com")).
+					WithStyle(FlexButtonStyleLink).
+					WithHeight(FlexButtonHeightSm),
+			)),
+	))
+
+	b, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(b))
+}
@@ -0,0 +1,358 @@
+package line
+
+type (
+	FlexContainerType string
+	FlexComponentType string
+)
+
+const (
+	BubbleFlexContainerType   FlexContainerType = "bubble"
+	CarouselFlexContainerType FlexContainerType = "carousel"
+
+	BoxFlexComponentType       FlexComponentType = "box"
+	ButtonFlexComponentType    FlexComponentType = "button"
+	ImageFlexComponentType     FlexComponentType = "image"
+	IconFlexComponentType      FlexComponentType = "icon"
+	TextFlexComponentType      FlexComponentType = "text"
+	SpanFlexComponentType      FlexComponentType = "span"
+	SeparatorFlexComponentType FlexComponentType = "separator"
+	FillerFlexComponentType    FlexComponentType = "filler"
+	VideoFlexComponentType     FlexComponentType = "video"
+)
+
+type (
+	FlexBubbleSize      string
+	FlexBubbleDirection string
+	FlexBoxLayout       string
+	FlexPosition        string
+	FlexAlign           string
+	FlexGravity         string
+	FlexJustifyContent  string
+	FlexAlignItems      string
+	FlexButtonStyle     string
+	FlexButtonHeight    string
+	FlexAdjustMode      string
+	FlexImageAspectMode string
+	FlexTextWeight      string
+	FlexTextStyle       string
+	FlexTextDecoration  string
+)
+
+const (
+	FlexBubbleSizeNano  FlexBubbleSize = "nano"
+	FlexBubbleSizeMicro FlexBubbleSize = "micro"
+	FlexBubbleSizeDeca  FlexBubbleSize = "deca"
+	FlexBubbleSizeHecto FlexBubbleSize = "hecto"
+	FlexBubbleSizeKilo  FlexBubbleSize = "kilo"
+	FlexBubbleSizeMega  FlexBubbleSize = "mega"
+	FlexBubbleSizeGiga  FlexBubbleSize = "giga"
+
+	FlexBubbleDirectionLTR FlexBubbleDirection = "ltr"
+	FlexBubbleDirectionRTL FlexBubbleDirection = "rtl"
+
+	FlexBoxLayoutHorizontal FlexBoxLayout = "horizontal"
+	FlexBoxLayoutVertical   FlexBoxLayout = "vertical"
+	FlexBoxLayoutBaseline   FlexBoxLayout = "baseline"
+
+	FlexPositionRelative FlexPosition = "relative"
+	FlexPositionAbsolute FlexPosition = "absolute"
+
+	FlexAlignStart  FlexAlign = "start"
+	FlexAlignEnd    FlexAlign = "end"
+	FlexAlignCenter FlexAlign = "center"
+
+	FlexGravityTop    FlexGravity = "top"
+	FlexGravityBottom FlexGravity = "bottom"
+	FlexGravityCenter FlexGravity = "center"
+
+	FlexJustifyContentFlexStart    FlexJustifyContent = "flex-start"
+	FlexJustifyContentCenter       FlexJustifyContent = "center"
+	FlexJustifyContentFlexEnd      FlexJustifyContent = "flex-end"
+	FlexJustifyContentSpaceBetween FlexJustifyContent = "space-between"
+	FlexJustifyContentSpaceAround  FlexJustifyContent = "space-around"
+	FlexJustifyContentSpaceEvenly  FlexJustifyContent = "space-evenly"
+
+	FlexAlignItemsFlexStart FlexAlignItems = "flex-start"
+	FlexAlignItemsCenter    FlexAlignItems = "center"
+	FlexAlignItemsFlexEnd   FlexAlignItems = "flex-end"
+
+	FlexButtonStylePrimary   FlexButtonStyle = "primary"
+	FlexButtonStyleSecondary FlexButtonStyle = "secondary"
+	FlexButtonStyleLink      FlexButtonStyle = "link"
+
+	FlexButtonHeightMd FlexButtonHeight = "md"
+	FlexButtonHeightSm FlexButtonHeight = "sm"
+
+	FlexAdjustModeShrinkToFit FlexAdjustMode = "shrink-to-fit"
+
+	FlexImageAspectModeCover FlexImageAspectMode = "cover"
+	FlexImageAspectModeFit   FlexImageAspectMode = "fit"
+
+	FlexTextWeightRegular FlexTextWeight = "regular"
+	FlexTextWeightBold    FlexTextWeight = "bold"
+
+	FlexTextStyleNormal FlexTextStyle = "normal"
+	FlexTextStyleItalic FlexTextStyle = "italic"
+
+	FlexTextDecorationNone        FlexTextDecoration = "none"
+	FlexTextDecorationUnderline   FlexTextDecoration = "underline"
+	FlexTextDecorationLineThrough FlexTextDecoration = "line-through"
+)
+
+// FlexMessage https://developers.line.biz/en/reference/messaging-api/#flex-message
+type FlexMessage struct {
+	Type       MessageType   `json:"type"`
+	AltText    string        `json:"altText"`
+	Contents   FlexContainer `json:"contents"`
+	QuickReply *QuickReply   `json:"quickReply,omitempty"`
+	Sender     *Sender       `json:"sender,omitempty"`
+}
+
+func (FlexMessage) MessageType() MessageType {
+	return FlexMessageType
+}
+
+// FlexContainer is either a *BubbleContainer or a *CarouselContainer.
+type FlexContainer interface {
+	FlexContainerType() FlexContainerType
+}
+
+// FlexComponent is any of the flex components, e.g. *BoxComponent or *TextComponent.
+type FlexComponent interface {
+	FlexComponentType() FlexComponentType
+}
+
+// BubbleContainer https://developers.line.biz/en/reference/messaging-api/#bubble
+type BubbleContainer struct {
+	Type      FlexContainerType   `json:"type"`
+	Size      FlexBubbleSize      `json:"size,omitempty"`
+	Direction FlexBubbleDirection `json:"direction,omitempty"`
+	Header    *BoxComponent       `json:"header,omitempty"`
+	Hero      FlexComponent       `json:"hero,omitempty"` // *BoxComponent, *ImageComponent or *VideoComponent
+	Body      *BoxComponent       `json:"body,omitempty"`
+	Footer    *BoxComponent       `json:"footer,omitempty"`
+	Styles    *BubbleStyle        `json:"styles,omitempty"`
+	Action    Action              `json:"action,omitempty"`
+}
+
+func (BubbleContainer) FlexContainerType() FlexContainerType {
+	return BubbleFlexContainerType
+}
+
+// CarouselContainer https://developers.line.biz/en/reference/messaging-api/#f-carousel
+type CarouselContainer struct {
+	Type     FlexContainerType  `json:"type"`
+	Contents []*BubbleContainer `json:"contents"`
+}
+
+func (CarouselContainer) FlexContainerType() FlexContainerType {
+	return CarouselFlexContainerType
+}
+
+// BubbleStyle https://developers.line.biz/en/reference/messaging-api/#bubble-style
+type BubbleStyle struct {
+	Header *BlockStyle `json:"header,omitempty"`
+	Hero   *BlockStyle `json:"hero,omitempty"`
+	Body   *BlockStyle `json:"body,omitempty"`
+	Footer *BlockStyle `json:"footer,omitempty"`
+}
+
+type BlockStyle struct {
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+	Separator       bool   `json:"separator,omitempty"`
+	SeparatorColor  string `json:"separatorColor,omitempty"`
+}
+
+// BoxComponent https://developers.line.biz/en/reference/messaging-api/#box
+type BoxComponent struct {
+	Type            FlexComponentType  `json:"type"`
+	Layout          FlexBoxLayout      `json:"layout"`
+	Contents        []FlexComponent    `json:"contents"`
+	BackgroundColor string             `json:"backgroundColor,omitempty"`
+	BorderColor     string             `json:"borderColor,omitempty"`
+	BorderWidth     string             `json:"borderWidth,omitempty"`
+	CornerRadius    string             `json:"cornerRadius,omitempty"`
+	Width           string             `json:"width,omitempty"`
+	MaxWidth        string             `json:"maxWidth,omitempty"`
+	Height          string             `json:"height,omitempty"`
+	MaxHeight       string             `json:"maxHeight,omitempty"`
+	Flex            *int               `json:"flex,omitempty"`
+	Spacing         string             `json:"spacing,omitempty"`
+	Margin          string             `json:"margin,omitempty"`
+	PaddingAll      string             `json:"paddingAll,omitempty"`
+	PaddingTop      string             `json:"paddingTop,omitempty"`
+	PaddingBottom   string             `json:"paddingBottom,omitempty"`
+	PaddingStart    string             `json:"paddingStart,omitempty"`
+	PaddingEnd      string             `json:"paddingEnd,omitempty"`
+	Position        FlexPosition       `json:"position,omitempty"`
+	OffsetTop       string             `json:"offsetTop,omitempty"`
+	OffsetBottom    string             `json:"offsetBottom,omitempty"`
+	OffsetStart     string             `json:"offsetStart,omitempty"`
+	OffsetEnd       string             `json:"offsetEnd,omitempty"`
+	Action          Action             `json:"action,omitempty"`
+	JustifyContent  FlexJustifyContent `json:"justifyContent,omitempty"`
+	AlignItems      FlexAlignItems     `json:"alignItems,omitempty"`
+	Background      *BoxBackground     `json:"background,omitempty"`
+}
+
+func (BoxComponent) FlexComponentType() FlexComponentType {
+	return BoxFlexComponentType
+}
+
+// BoxBackground https://developers.line.biz/en/reference/messaging-api/#box-background
+type BoxBackground struct {
+	Type           string `json:"type"` // linearGradient
+	Angle          string `json:"angle"`
+	StartColor     string `json:"startColor"`
+	EndColor       string `json:"endColor"`
+	CenterColor    string `json:"centerColor,omitempty"`
+	CenterPosition string `json:"centerPosition,omitempty"`
+}
+
+// ButtonComponent https://developers.line.biz/en/reference/messaging-api/#button
+type ButtonComponent struct {
+	Type         FlexComponentType `json:"type"`
+	Action       Action            `json:"action"`
+	Flex         *int              `json:"flex,omitempty"`
+	Margin       string            `json:"margin,omitempty"`
+	Position     FlexPosition      `json:"position,omitempty"`
+	OffsetTop    string            `json:"offsetTop,omitempty"`
+	OffsetBottom string            `json:"offsetBottom,omitempty"`
+	OffsetStart  string            `json:"offsetStart,omitempty"`
+	OffsetEnd    string            `json:"offsetEnd,omitempty"`
+	Height       FlexButtonHeight  `json:"height,omitempty"`
+	Style        FlexButtonStyle   `json:"style,omitempty"`
+	Color        string            `json:"color,omitempty"`
+	Gravity      FlexGravity       `json:"gravity,omitempty"`
+	AdjustMode   FlexAdjustMode    `json:"adjustMode,omitempty"`
+	Scaling      bool              `json:"scaling,omitempty"`
+}
+
+func (ButtonComponent) FlexComponentType() FlexComponentType {
+	return ButtonFlexComponentType
+}
+
+// ImageComponent https://developers.line.biz/en/reference/messaging-api/#f-image
+type ImageComponent struct {
+	Type            FlexComponentType   `json:"type"`
+	URL             string              `json:"url"`
+	Flex            *int                `json:"flex,omitempty"`
+	Margin          string              `json:"margin,omitempty"`
+	Position        FlexPosition        `json:"position,omitempty"`
+	OffsetTop       string              `json:"offsetTop,omitempty"`
+	OffsetBottom    string              `json:"offsetBottom,omitempty"`
+	OffsetStart     string              `json:"offsetStart,omitempty"`
+	OffsetEnd       string              `json:"offsetEnd,omitempty"`
+	Align           FlexAlign           `json:"align,omitempty"`
+	Gravity         FlexGravity         `json:"gravity,omitempty"`
+	Size            string              `json:"size,omitempty"`
+	AspectRatio     string              `json:"aspectRatio,omitempty"`
+	AspectMode      FlexImageAspectMode `json:"aspectMode,omitempty"`
+	BackgroundColor string              `json:"backgroundColor,omitempty"`
+	Action          Action              `json:"action,omitempty"`
+	Animated        bool                `json:"animated,omitempty"`
+}
+
+func (ImageComponent) FlexComponentType() FlexComponentType {
+	return ImageFlexComponentType
+}
+
+// IconComponent https://developers.line.biz/en/reference/messaging-api/#icon
+type IconComponent struct {
+	Type         FlexComponentType `json:"type"`
+	URL          string            `json:"url"`
+	Margin       string            `json:"margin,omitempty"`
+	Position     FlexPosition      `json:"position,omitempty"`
+	OffsetTop    string            `json:"offsetTop,omitempty"`
+	OffsetBottom string            `json:"offsetBottom,omitempty"`
+	OffsetStart  string            `json:"offsetStart,omitempty"`
+	OffsetEnd    string            `json:"offsetEnd,omitempty"`
+	Size         string            `json:"size,omitempty"`
+	AspectRatio  string            `json:"aspectRatio,omitempty"`
+	Scaling      bool              `json:"scaling,omitempty"`
+}
+
+func (IconComponent) FlexComponentType() FlexComponentType {
+	return IconFlexComponentType
+}
+
+// TextComponent https://developers.line.biz/en/reference/messaging-api/#f-text
+type TextComponent struct {
+	Type         FlexComponentType  `json:"type"`
+	Text         string             `json:"text,omitempty"`
+	Contents     []*SpanComponent   `json:"contents,omitempty"`
+	AdjustMode   FlexAdjustMode     `json:"adjustMode,omitempty"`
+	Flex         *int               `json:"flex,omitempty"`
+	Margin       string             `json:"margin,omitempty"`
+	Position     FlexPosition       `json:"position,omitempty"`
+	OffsetTop    string             `json:"offsetTop,omitempty"`
+	OffsetBottom string             `json:"offsetBottom,omitempty"`
+	OffsetStart  string             `json:"offsetStart,omitempty"`
+	OffsetEnd    string             `json:"offsetEnd,omitempty"`
+	Size         string             `json:"size,omitempty"`
+	Align        FlexAlign          `json:"align,omitempty"`
+	Gravity      FlexGravity        `json:"gravity,omitempty"`
+	Wrap         bool               `json:"wrap,omitempty"`
+	LineSpacing  string             `json:"lineSpacing,omitempty"`
+	MaxLines     int                `json:"maxLines,omitempty"`
+	Weight       FlexTextWeight     `json:"weight,omitempty"`
+	Color        string             `json:"color,omitempty"`
+	Action       Action             `json:"action,omitempty"`
+	Style        FlexTextStyle      `json:"style,omitempty"`
+	Decoration   FlexTextDecoration `json:"decoration,omitempty"`
+	Scaling      bool               `json:"scaling,omitempty"`
+}
+
+func (TextComponent) FlexComponentType() FlexComponentType {
+	return TextFlexComponentType
+}
+
+// SpanComponent https://developers.line.biz/en/reference/messaging-api/#span
+type SpanComponent struct {
+	Type       FlexComponentType  `json:"type"`
+	Text       string             `json:"text"`
+	Size       string             `json:"size,omitempty"`
+	Color      string             `json:"color,omitempty"`
+	Weight     FlexTextWeight     `json:"weight,omitempty"`
+	Style      FlexTextStyle      `json:"style,omitempty"`
+	Decoration FlexTextDecoration `json:"decoration,omitempty"`
+}
+
+func (SpanComponent) FlexComponentType() FlexComponentType {
+	return SpanFlexComponentType
+}
+
+// SeparatorComponent https://developers.line.biz/en/reference/messaging-api/#separator
+type SeparatorComponent struct {
+	Type   FlexComponentType `json:"type"`
+	Margin string            `json:"margin,omitempty"`
+	Color  string            `json:"color,omitempty"`
+}
+
+func (SeparatorComponent) FlexComponentType() FlexComponentType {
+	return SeparatorFlexComponentType
+}
+
+// FillerComponent https://developers.line.biz/en/reference/messaging-api/#filler
+type FillerComponent struct {
+	Type FlexComponentType `json:"type"`
+	Flex *int              `json:"flex,omitempty"`
+}
+
+func (FillerComponent) FlexComponentType() FlexComponentType {
+	return FillerFlexComponentType
+}
+
+// VideoComponent https://developers.line.biz/en/reference/messaging-api/#f-video
+type VideoComponent struct {
+	Type        FlexComponentType `json:"type"`
+	URL         string            `json:"url"`
+	PreviewURL  string            `json:"previewUrl"`
+	AltContent  FlexComponent     `json:"altContent"` // *ImageComponent or *BoxComponent
+	AspectRatio string            `json:"aspectRatio,omitempty"`
+	Action      Action            `json:"action,omitempty"`
+}
+
+func (VideoComponent) FlexComponentType() FlexComponentType {
+	return VideoFlexComponentType
+}
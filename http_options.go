@@ -53,9 +53,21 @@ func WithRetryableHTTPClientBackoff(backoff retryablehttp.Backoff) RetryableHTTP
 	}
 }
 
+// WithRetryableHTTPClientErrorHandler allows setting a custom handler for
+// the last response once the retries are exhausted
+func WithRetryableHTTPClientErrorHandler(errorHandler retryablehttp.ErrorHandler) RetryableHTTPClientOption {
+	return func(client *retryablehttp.Client) {
+		client.ErrorHandler = errorHandler
+	}
+}
+
 func NewRetryableHTTPClient(opts ...RetryableHTTPClientOption) *http.Client {
 	retryClient := retryablehttp.NewClient()
 	retryClient.Logger = nil
+	// Hand the last response back once the retries are exhausted, so that
+	// CheckResponse can turn it into an ErrorResponse instead of a generic
+	// "giving up" error.
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
 	for _, opt := range opts {
 		opt(retryClient)
 	}
@@ -0,0 +1,166 @@
+package line
+
+// MaxQuickReplyItems is the maximum number of items of a quick reply.
+const MaxQuickReplyItems = 13
+
+// NewQuickReply returns a quick reply with the given buttons.
+func NewQuickReply(items ...QuickReplyItem) *QuickReply {
+	return &QuickReply{Items: items}
+}
+
+// NewQuickReplyItem returns a quick reply button performing the given action.
+func NewQuickReplyItem(action Action) QuickReplyItem {
+	return QuickReplyItem{Type: "action", Action: action}
+}
+
+// WithImageURL sets the icon shown at the beginning of the button.
+func (i QuickReplyItem) WithImageURL(imageURL string) QuickReplyItem {
+	i.ImageURL = imageURL
+	return i
+}
+
+// NewTextMessage returns a text message.
+func NewTextMessage(text string) *TextMessage {
+	return &TextMessage{Type: TextMessageType, Text: text}
+}
+
+// NewEmojiMessage returns a text message containing LINE emojis at the
+// positions of the "$" placeholders in text.
+func NewEmojiMessage(text string, emojis ...Emoji) *EmojiMessage {
+	return &EmojiMessage{Type: TextMessageType, Text: text, Emojis: emojis}
+}
+
+// NewStickerMessage returns a sticker message.
+func NewStickerMessage(packageID, stickerID string) *StickerMessage {
+	return &StickerMessage{Type: StickerMessageType, PackageID: packageID, StickerID: stickerID}
+}
+
+// NewImageMessage returns an image message.
+func NewImageMessage(originalContentURL, previewImageURL string) *ImageMessage {
+	return &ImageMessage{Type: ImageMessageType, OriginalContentURL: originalContentURL, PreviewImageURL: previewImageURL}
+}
+
+// NewVideoMessage returns a video message.
+func NewVideoMessage(originalContentURL, previewImageURL string) *VideoMessage {
+	return &VideoMessage{Type: VideoMessageType, OriginalContentURL: originalContentURL, PreviewImageURL: previewImageURL}
+}
+
+// NewAudioMessage returns an audio message. duration is in milliseconds.
+func NewAudioMessage(originalContentURL string, duration int) *AudioMessage {
+	return &AudioMessage{Type: AudioMessageType, OriginalContentURL: originalContentURL, Duration: duration}
+}
+
+// NewLocationMessage returns a location message.
+func NewLocationMessage(title, address string, latitude, longitude float64) *LocationMessage {
+	return &LocationMessage{Type: LocationMessageType, Title: title, Address: address, Latitude: latitude, Longitude: longitude}
+}
+
+// NewImagemapMessage returns an imagemap message.
+func NewImagemapMessage(baseURL, altText string, baseSize ImagemapBaseSize, actions ...ImagemapAction) *ImagemapMessage {
+	return &ImagemapMessage{Type: ImagemapMessageType, BaseURL: baseURL, AltText: altText, BaseSize: baseSize, Actions: actions}
+}
+
+// NewTemplateMessage returns a template message.
+func NewTemplateMessage(altText string, template Template) *TemplateMessage {
+	return &TemplateMessage{Type: TemplateMessageType, AltText: altText, Template: template}
+}
+
+func (m *TextMessage) WithQuickReply(quickReply *QuickReply) *TextMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *TextMessage) WithSender(sender *Sender) *TextMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *EmojiMessage) WithQuickReply(quickReply *QuickReply) *EmojiMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *EmojiMessage) WithSender(sender *Sender) *EmojiMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *StickerMessage) WithQuickReply(quickReply *QuickReply) *StickerMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *StickerMessage) WithSender(sender *Sender) *StickerMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *ImageMessage) WithQuickReply(quickReply *QuickReply) *ImageMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *ImageMessage) WithSender(sender *Sender) *ImageMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *VideoMessage) WithQuickReply(quickReply *QuickReply) *VideoMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *VideoMessage) WithSender(sender *Sender) *VideoMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *AudioMessage) WithQuickReply(quickReply *QuickReply) *AudioMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *AudioMessage) WithSender(sender *Sender) *AudioMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *LocationMessage) WithQuickReply(quickReply *QuickReply) *LocationMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *LocationMessage) WithSender(sender *Sender) *LocationMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *ImagemapMessage) WithQuickReply(quickReply *QuickReply) *ImagemapMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *ImagemapMessage) WithSender(sender *Sender) *ImagemapMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *TemplateMessage) WithQuickReply(quickReply *QuickReply) *TemplateMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *TemplateMessage) WithSender(sender *Sender) *TemplateMessage {
+	m.Sender = sender
+	return m
+}
+
+func (m *FlexMessage) WithQuickReply(quickReply *QuickReply) *FlexMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+func (m *FlexMessage) WithSender(sender *Sender) *FlexMessage {
+	m.Sender = sender
+	return m
+}
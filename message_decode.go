@@ -0,0 +1,542 @@
+package line
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Messages is a list of messages that can be unmarshalled from JSON. Every
+// element is decoded into the concrete type named by its "type" property,
+// e.g. *TextMessage or *TemplateMessage.
+type Messages []Message
+
+func (m *Messages) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+	if raws == nil {
+		*m = nil
+		return nil
+	}
+
+	messages := make(Messages, len(raws))
+	for i, raw := range raws {
+		msg, err := UnmarshalMessage(raw)
+		if err != nil {
+			return fmt.Errorf("messages[%d]: %w", i, err)
+		}
+		messages[i] = msg
+	}
+	*m = messages
+	return nil
+}
+
+// UnmarshalMessage decodes a single message object into its concrete type.
+// Text messages carrying emojis are decoded into *EmojiMessage.
+func UnmarshalMessage(data []byte) (Message, error) {
+	var probe struct {
+		Type   MessageType     `json:"type"`
+		Emojis json.RawMessage `json:"emojis"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	var msg Message
+	switch probe.Type {
+	case TextMessageType:
+		if probe.Emojis != nil {
+			msg = new(EmojiMessage)
+		} else {
+			msg = new(TextMessage)
+		}
+	case StickerMessageType:
+		msg = new(StickerMessage)
+	case ImageMessageType:
+		msg = new(ImageMessage)
+	case VideoMessageType:
+		msg = new(VideoMessage)
+	case AudioMessageType:
+		msg = new(AudioMessage)
+	case LocationMessageType:
+		msg = new(LocationMessage)
+	case ImagemapMessageType:
+		msg = new(ImagemapMessage)
+	case TemplateMessageType:
+		msg = new(TemplateMessage)
+	case FlexMessageType:
+		msg = new(FlexMessage)
+	case "":
+		return nil, fmt.Errorf("line: message type is missing")
+	default:
+		return nil, fmt.Errorf("line: unknown message type %q", probe.Type)
+	}
+
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// UnmarshalTemplate decodes a template object into *ButtonTemplate,
+// *ConfirmTemplate, *CarouselTemplate or *ImageCarouselTemplate.
+func UnmarshalTemplate(data []byte) (Template, error) {
+	typ, err := unmarshalType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var tmpl Template
+	switch TemplateType(typ) {
+	case ButtonTemplateType:
+		tmpl = new(ButtonTemplate)
+	case ConfirmTemplateType:
+		tmpl = new(ConfirmTemplate)
+	case CarouselTemplateType:
+		tmpl = new(CarouselTemplate)
+	case ImageCarouselTemplateType:
+		tmpl = new(ImageCarouselTemplate)
+	default:
+		return nil, fmt.Errorf("line: unknown template type %q", typ)
+	}
+
+	if err := json.Unmarshal(data, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// UnmarshalAction decodes an action object into its concrete type, e.g.
+// *PostbackAction or *URIAction.
+func UnmarshalAction(data []byte) (Action, error) {
+	typ, err := unmarshalType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var action Action
+	switch ActionType(typ) {
+	case PostbackActionType:
+		action = new(PostbackAction)
+	case MessageActionType:
+		action = new(MessageAction)
+	case URIActionType:
+		action = new(URIAction)
+	case DatetimePickerActionType:
+		action = new(DatetimePickerAction)
+	case CameraActionType:
+		action = new(CameraAction)
+	case CameraRollActionType:
+		action = new(CameraRollAction)
+	case LocationActionType:
+		action = new(LocationAction)
+	case RichMenuSwitchActionType:
+		action = new(RichMenuSwitchAction)
+	case ClipboardActionType:
+		action = new(ClipboardAction)
+	default:
+		return nil, fmt.Errorf("line: unknown action type %q", typ)
+	}
+
+	if err := json.Unmarshal(data, action); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+// UnmarshalImagemapAction decodes an imagemap action into *URIImagemapAction
+// *MessageImagemapAction or *ClipboardImagemapAction.
+func UnmarshalImagemapAction(data []byte) (ImagemapAction, error) {
+	typ, err := unmarshalType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var action ImagemapAction
+	switch ImagemapActionType(typ) {
+	case URIImagemapActionType:
+		action = new(URIImagemapAction)
+	case MessageImagemapActionType:
+		action = new(MessageImagemapAction)
+	case ClipboardImagemapActionType:
+		action = new(ClipboardImagemapAction)
+	default:
+		return nil, fmt.Errorf("line: unknown imagemap action type %q", typ)
+	}
+
+	if err := json.Unmarshal(data, action); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+// UnmarshalFlexContainer decodes a flex container into *BubbleContainer or
+// *CarouselContainer.
+func UnmarshalFlexContainer(data []byte) (FlexContainer, error) {
+	typ, err := unmarshalType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var container FlexContainer
+	switch FlexContainerType(typ) {
+	case BubbleFlexContainerType:
+		container = new(BubbleContainer)
+	case CarouselFlexContainerType:
+		container = new(CarouselContainer)
+	default:
+		return nil, fmt.Errorf("line: unknown flex container type %q", typ)
+	}
+
+	if err := json.Unmarshal(data, container); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// UnmarshalFlexComponent decodes a flex component into its concrete type,
+// e.g. *BoxComponent or *TextComponent.
+func UnmarshalFlexComponent(data []byte) (FlexComponent, error) {
+	typ, err := unmarshalType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var component FlexComponent
+	switch FlexComponentType(typ) {
+	case BoxFlexComponentType:
+		component = new(BoxComponent)
+	case ButtonFlexComponentType:
+		component = new(ButtonComponent)
+	case ImageFlexComponentType:
+		component = new(ImageComponent)
+	case IconFlexComponentType:
+		component = new(IconComponent)
+	case TextFlexComponentType:
+		component = new(TextComponent)
+	case SpanFlexComponentType:
+		component = new(SpanComponent)
+	case SeparatorFlexComponentType:
+		component = new(SeparatorComponent)
+	case FillerFlexComponentType:
+		component = new(FillerComponent)
+	case VideoFlexComponentType:
+		component = new(VideoComponent)
+	default:
+		return nil, fmt.Errorf("line: unknown flex component type %q", typ)
+	}
+
+	if err := json.Unmarshal(data, component); err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+func (m *TemplateMessage) UnmarshalJSON(data []byte) error {
+	type alias TemplateMessage
+	raw := struct {
+		*alias
+		Template json.RawMessage `json:"template"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Template = nil
+	if isJSONNull(raw.Template) {
+		return nil
+	}
+	tmpl, err := UnmarshalTemplate(raw.Template)
+	if err != nil {
+		return err
+	}
+	m.Template = tmpl
+	return nil
+}
+
+func (m *ImagemapMessage) UnmarshalJSON(data []byte) error {
+	type alias ImagemapMessage
+	raw := struct {
+		*alias
+		Actions []json.RawMessage `json:"actions"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Actions = make([]ImagemapAction, len(raw.Actions))
+	for i, a := range raw.Actions {
+		action, err := UnmarshalImagemapAction(a)
+		if err != nil {
+			return err
+		}
+		m.Actions[i] = action
+	}
+	return nil
+}
+
+func (m *FlexMessage) UnmarshalJSON(data []byte) error {
+	type alias FlexMessage
+	raw := struct {
+		*alias
+		Contents json.RawMessage `json:"contents"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Contents = nil
+	if isJSONNull(raw.Contents) {
+		return nil
+	}
+	contents, err := UnmarshalFlexContainer(raw.Contents)
+	if err != nil {
+		return err
+	}
+	m.Contents = contents
+	return nil
+}
+
+func (c *BubbleContainer) UnmarshalJSON(data []byte) error {
+	type alias BubbleContainer
+	raw := struct {
+		*alias
+		Hero   json.RawMessage `json:"hero"`
+		Action json.RawMessage `json:"action"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.Hero = nil
+	if !isJSONNull(raw.Hero) {
+		hero, err := UnmarshalFlexComponent(raw.Hero)
+		if err != nil {
+			return err
+		}
+		c.Hero = hero
+	}
+
+	var err error
+	c.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func (c *BoxComponent) UnmarshalJSON(data []byte) error {
+	type alias BoxComponent
+	raw := struct {
+		*alias
+		Contents []json.RawMessage `json:"contents"`
+		Action   json.RawMessage   `json:"action"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.Contents = make([]FlexComponent, len(raw.Contents))
+	for i, content := range raw.Contents {
+		component, err := UnmarshalFlexComponent(content)
+		if err != nil {
+			return err
+		}
+		c.Contents[i] = component
+	}
+
+	var err error
+	c.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func (c *VideoComponent) UnmarshalJSON(data []byte) error {
+	type alias VideoComponent
+	raw := struct {
+		*alias
+		AltContent json.RawMessage `json:"altContent"`
+		Action     json.RawMessage `json:"action"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.AltContent = nil
+	if !isJSONNull(raw.AltContent) {
+		altContent, err := UnmarshalFlexComponent(raw.AltContent)
+		if err != nil {
+			return err
+		}
+		c.AltContent = altContent
+	}
+
+	var err error
+	c.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func (t *ButtonTemplate) UnmarshalJSON(data []byte) error {
+	type alias ButtonTemplate
+	raw := struct {
+		*alias
+		Actions       []json.RawMessage `json:"actions"`
+		DefaultAction json.RawMessage   `json:"defaultAction"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if t.Actions, err = unmarshalActions(raw.Actions); err != nil {
+		return err
+	}
+	t.DefaultAction, err = unmarshalOptionalAction(raw.DefaultAction)
+	return err
+}
+
+func (t *ConfirmTemplate) UnmarshalJSON(data []byte) error {
+	type alias ConfirmTemplate
+	raw := struct {
+		*alias
+		Actions []json.RawMessage `json:"actions"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if t.Actions, err = unmarshalActions(raw.Actions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CarouselColumn) UnmarshalJSON(data []byte) error {
+	type alias CarouselColumn
+	raw := struct {
+		*alias
+		Actions       []json.RawMessage `json:"actions"`
+		DefaultAction json.RawMessage   `json:"defaultAction"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if c.Actions, err = unmarshalActions(raw.Actions); err != nil {
+		return err
+	}
+	c.DefaultAction, err = unmarshalOptionalAction(raw.DefaultAction)
+	return err
+}
+
+func (c *ImageCarouselColumn) UnmarshalJSON(data []byte) error {
+	type alias ImageCarouselColumn
+	raw := struct {
+		*alias
+		Action json.RawMessage `json:"action"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	c.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func (i *QuickReplyItem) UnmarshalJSON(data []byte) error {
+	type alias QuickReplyItem
+	raw := struct {
+		*alias
+		Action json.RawMessage `json:"action"`
+	}{alias: (*alias)(i)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	i.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func (c *ButtonComponent) UnmarshalJSON(data []byte) error {
+	type alias ButtonComponent
+	raw := struct {
+		*alias
+		Action json.RawMessage `json:"action"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	c.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func (c *ImageComponent) UnmarshalJSON(data []byte) error {
+	type alias ImageComponent
+	raw := struct {
+		*alias
+		Action json.RawMessage `json:"action"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	c.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func (c *TextComponent) UnmarshalJSON(data []byte) error {
+	type alias TextComponent
+	raw := struct {
+		*alias
+		Action json.RawMessage `json:"action"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	c.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+func unmarshalActions(raws []json.RawMessage) ([]Action, error) {
+	if raws == nil {
+		return nil, nil
+	}
+	actions := make([]Action, len(raws))
+	for i, raw := range raws {
+		action, err := UnmarshalAction(raw)
+		if err != nil {
+			return nil, err
+		}
+		actions[i] = action
+	}
+	return actions, nil
+}
+
+func unmarshalOptionalAction(raw json.RawMessage) (Action, error) {
+	if isJSONNull(raw) {
+		return nil, nil
+	}
+	return UnmarshalAction(raw)
+}
+
+func unmarshalType(data []byte) (string, error) {
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", err
+	}
+	return probe.Type, nil
+}
+
+func isJSONNull(data json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
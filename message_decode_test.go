@@ -0,0 +1,119 @@
+package line
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalMessagePushOptions(t *testing.T) {
+	message := `{
+		"to":"U1234567890",
+		"messages":[
+			{"type":"text","text":"Hello, World!"},
+			{"type":"text","text":"$ LINE emoji $","emojis":[{"index":0,"productId":"5ac1bfd5040ab15980c9b435","emojiId":"001"}]},
+			{"type":"template","altText":"this is a confirm template","template":{"type":"confirm","text":"Are you sure?","actions":[{"type":"message","label":"Yes","text":"yes"},{"type":"message","label":"No","text":"no"}]}},
+			{"type":"flex","altText":"flex","contents":{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"hello"}]}}}
+		]
+	}`
+
+	var opt MessagePushOptions
+	require.NoError(t, json.Unmarshal([]byte(message), &opt))
+	require.Len(t, opt.Messages, 4)
+
+	assert.Equal(t, &TextMessage{Type: TextMessageType, Text: "Hello, World!"}, opt.Messages[0])
+	assert.Equal(t, &EmojiMessage{
+		Type:   TextMessageType,
+		Text:   "$ LINE emoji $",
+		Emojis: []Emoji{{Index: 0, ProductID: "5ac1bfd5040ab15980c9b435", EmojiID: "001"}},
+	}, opt.Messages[1])
+	assert.Equal(t, &TemplateMessage{
+		Type:    TemplateMessageType,
+		AltText: "this is a confirm template",
+		Template: &ConfirmTemplate{
+			Type: "confirm",
+			Text: "Are you sure?",
+			Actions: []Action{
+				NewMessageAction("Yes", "yes"),
+				NewMessageAction("No", "no"),
+			},
+		},
+	}, opt.Messages[2])
+	assert.Equal(t, NewFlexMessage("flex", NewFlexBubble().WithBody(
+		NewFlexBox(FlexBoxLayoutVertical, NewFlexText("hello")),
+	)), opt.Messages[3])
+}
+
+func TestUnmarshalMessageRoundTrip(t *testing.T) {
+	messages := Messages{
+		&StickerMessage{Type: StickerMessageType, PackageID: "446", StickerID: "1988"},
+		&TemplateMessage{
+			Type:    TemplateMessageType,
+			AltText: "this is a image carousel template",
+			Template: &ImageCarouselTemplate{
+				Type: "image_carousel",
+				Columns: []ImageCarouselColumn{
+					{ImageURL: "https://example.com/bot/images/item1.jpg", Action: NewPostbackAction("Buy", "action=buy&itemid=111")},
+				},
+			},
+		},
+		NewFlexMessage("flex", NewFlexCarousel(NewFlexBubble().
+			WithHero(NewFlexVideo("https://example.com/video.mp4", "https://example.com/preview.jpg",
+				NewFlexImage("https://example.com/alt.jpg"))).
+			WithFooter(NewFlexBox(FlexBoxLayoutHorizontal, NewFlexSeparator(), NewFlexFiller())))),
+	}
+
+	b, err := json.Marshal(messages)
+	require.NoError(t, err)
+
+	var decoded Messages
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, messages, decoded)
+}
+
+func TestUnmarshalMessageUnknownType(t *testing.T) {
+	_, err := UnmarshalMessage([]byte(`{"type":"unknown"}`))
+	assert.EqualError(t, err, `line: unknown message type "unknown"`)
+
+	var opt MessagePushOptions
+	err = json.Unmarshal([]byte(`{"messages":[{"text":"no type"}]}`), &opt)
+	assert.EqualError(t, err, "messages[0]: line: message type is missing")
+}
+
+func TestUnmarshalActions(t *testing.T) {
+	data := `{"type":"buttons","text":"Please select","actions":[
+		{"type":"postback","label":"Buy","data":"action=buy","displayText":"Buy","inputOption":"openKeyboard","fillInText":"---\nName: "},
+		{"type":"uri","label":"View","uri":"https://example.com/mobile","altUri":{"desktop":"https://example.com/desktop"}},
+		{"type":"datetimepicker","label":"Select date","data":"storeId=12345","mode":"datetime","initial":"2017-12-25t00:00","max":"2018-01-24t23:59","min":"2017-12-25t00:00"},
+		{"type":"clipboard","label":"Copy","clipboardText":"3B48740B"}
+	]}`
+
+	tmpl, err := UnmarshalTemplate([]byte(data))
+	require.NoError(t, err)
+
+	buttons, ok := tmpl.(*ButtonTemplate)
+	require.True(t, ok)
+	assert.Equal(t, []Action{
+		&PostbackAction{Type: PostbackActionType, Label: "Buy", Data: "action=buy", DisplayText: "Buy", InputOption: PostbackInputOptionOpenKeyboard, FillInText: "---\nName: "},
+		&URIAction{Type: URIActionType, Label: "View", URI: "https://example.com/mobile", AltURI: &AltURI{Desktop: "https://example.com/desktop"}},
+		&DatetimePickerAction{Type: DatetimePickerActionType, Label: "Select date", Data: "storeId=12345", Mode: DatetimePickerModeDatetime, Initial: "2017-12-25t00:00", Max: "2018-01-24t23:59", Min: "2017-12-25t00:00"},
+		NewClipboardAction("Copy", "3B48740B"),
+	}, buttons.Actions)
+
+	quickReply := NewQuickReply(
+		NewQuickReplyItem(NewCameraAction("Camera")),
+		NewQuickReplyItem(NewCameraRollAction("Camera roll")),
+		NewQuickReplyItem(NewLocationAction("Location")),
+	)
+	b, err := json.Marshal(quickReply)
+	require.NoError(t, err)
+
+	var decoded QuickReply
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, quickReply, &decoded)
+
+	_, err = UnmarshalAction([]byte(`{"type":"unknown"}`))
+	assert.EqualError(t, err, `line: unknown action type "unknown"`)
+}
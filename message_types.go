@@ -1,17 +1,64 @@
 package line
 
 type MessagePushOptions struct {
-	To       string    `json:"to,omitempty"`
-	Messages []Message `json:"messages,omitempty"`
+	To       string   `json:"to,omitempty"`
+	Messages Messages `json:"messages,omitempty"`
 }
 
 type ValidateMessagePushOptions struct {
-	Messages []Message `json:"messages,omitempty"`
+	Messages Messages `json:"messages,omitempty"`
+}
+
+// MessageReplyOptions https://developers.line.biz/en/reference/messaging-api/#send-reply-message
+type MessageReplyOptions struct {
+	ReplyToken           string   `json:"replyToken,omitempty"`
+	Messages             Messages `json:"messages,omitempty"`
+	NotificationDisabled bool     `json:"notificationDisabled,omitempty"`
+}
+
+type ValidateMessageReplyOptions struct {
+	Messages Messages `json:"messages,omitempty"`
+}
+
+// MessageMulticastOptions https://developers.line.biz/en/reference/messaging-api/#send-multicast-message
+type MessageMulticastOptions struct {
+	To                     []string `json:"to,omitempty"` // Max: 500 user IDs
+	Messages               Messages `json:"messages,omitempty"`
+	NotificationDisabled   bool     `json:"notificationDisabled,omitempty"`
+	CustomAggregationUnits []string `json:"customAggregationUnits,omitempty"`
+}
+
+type ValidateMessageMulticastOptions struct {
+	Messages Messages `json:"messages,omitempty"`
+}
+
+// MessageNarrowcastOptions https://developers.line.biz/en/reference/messaging-api/#send-narrowcast-message
+type MessageNarrowcastOptions struct {
+	Messages             Messages          `json:"messages,omitempty"`
+	Recipient            Recipient         `json:"recipient,omitempty"`
+	Filter               *NarrowcastFilter `json:"filter,omitempty"`
+	Limit                *NarrowcastLimit  `json:"limit,omitempty"`
+	NotificationDisabled bool              `json:"notificationDisabled,omitempty"`
+}
+
+type ValidateMessageNarrowcastOptions struct {
+	Messages Messages `json:"messages,omitempty"`
+}
+
+// MessageBroadcastOptions https://developers.line.biz/en/reference/messaging-api/#send-broadcast-message
+type MessageBroadcastOptions struct {
+	Messages             Messages `json:"messages,omitempty"`
+	NotificationDisabled bool     `json:"notificationDisabled,omitempty"`
+}
+
+type ValidateMessageBroadcastOptions struct {
+	Messages Messages `json:"messages,omitempty"`
 }
 
 type (
-	MessageType  string
-	TemplateType string
+	MessageType        string
+	TemplateType       string
+	ImagemapActionType string
 )
 
 const (
@@ -22,27 +69,65 @@ const (
 	AudioMessageType    MessageType = "audio"
 	LocationMessageType MessageType = "location"
 	TemplateMessageType MessageType = "template"
+	ImagemapMessageType MessageType = "imagemap"
+	FlexMessageType     MessageType = "flex"
 
 	ButtonTemplateType        TemplateType = "buttons"
 	ConfirmTemplateType       TemplateType = "confirm"
 	CarouselTemplateType      TemplateType = "carousel"
 	ImageCarouselTemplateType TemplateType = "image_carousel"
+
+	URIImagemapActionType       ImagemapActionType = "uri"
+	MessageImagemapActionType   ImagemapActionType = "message"
+	ClipboardImagemapActionType ImagemapActionType = "clipboard"
 )
 
-type Message interface{}
+// Message is implemented by every message type that can be sent.
+type Message interface {
+	MessageType() MessageType
+}
+
+// QuickReply https://developers.line.biz/en/reference/messaging-api/#quick-reply
+type QuickReply struct {
+	Items []QuickReplyItem `json:"items"` // Max: 13 items
+}
+
+type QuickReplyItem struct {
+	Type     string `json:"type"` // action
+	ImageURL string `json:"imageUrl,omitempty"`
+	Action   Action `json:"action"`
+}
+
+// Sender https://developers.line.biz/en/reference/messaging-api/#icon-nickname-switch
+type Sender struct {
+	Name    string `json:"name,omitempty"`
+	IconURL string `json:"iconUrl,omitempty"`
+}
 
 // TextMessage https://developers.line.biz/en/reference/messaging-api/#text-message
 type TextMessage struct {
 	Type       MessageType `json:"type,omitempty"`
 	Text       string      `json:"text,omitempty"`
 	QuoteToken string      `json:"quoteToken"`
+	QuickReply *QuickReply `json:"quickReply,omitempty"`
+	Sender     *Sender     `json:"sender,omitempty"`
+}
+
+func (TextMessage) MessageType() MessageType {
+	return TextMessageType
 }
 
 // EmojiMessage https://developers.line.biz/en/reference/messaging-api/#text-message
 type EmojiMessage struct {
-	Type   MessageType `json:"type,omitempty"`
-	Text   string      `json:"text,omitempty"`
-	Emojis []Emoji     `json:"emojis,omitempty"`
+	Type       MessageType `json:"type,omitempty"`
+	Text       string      `json:"text,omitempty"`
+	Emojis     []Emoji     `json:"emojis,omitempty"`
+	QuickReply *QuickReply `json:"quickReply,omitempty"`
+	Sender     *Sender     `json:"sender,omitempty"`
+}
+
+func (EmojiMessage) MessageType() MessageType {
+	return TextMessageType
 }
 
 type Emoji struct {
@@ -57,6 +142,12 @@ type StickerMessage struct {
 	PackageID  string      `json:"packageId"`
 	StickerID  string      `json:"stickerId"`
 	QuoteToken string      `json:"quoteToken,omitempty"`
+	QuickReply *QuickReply `json:"quickReply,omitempty"`
+	Sender     *Sender     `json:"sender,omitempty"`
+}
+
+func (StickerMessage) MessageType() MessageType {
+	return StickerMessageType
 }
 
 // ImageMessage https://developers.line.biz/en/reference/messaging-api/#image-message
@@ -64,6 +155,12 @@ type ImageMessage struct {
 	Type               MessageType `json:"type"`
 	OriginalContentURL string      `json:"originalContentUrl"`
 	PreviewImageURL    string      `json:"previewImageUrl"`
+	QuickReply         *QuickReply `json:"quickReply,omitempty"`
+	Sender             *Sender     `json:"sender,omitempty"`
+}
+
+func (ImageMessage) MessageType() MessageType {
+	return ImageMessageType
 }
 
 // VideoMessage https://developers.line.biz/en/reference/messaging-api/#video-message
@@ -72,6 +169,12 @@ type VideoMessage struct {
 	OriginalContentURL string      `json:"originalContentUrl"`
 	PreviewImageURL    string      `json:"previewImageUrl"`
 	TrackingID         string      `json:"trackingId"`
+	QuickReply         *QuickReply `json:"quickReply,omitempty"`
+	Sender             *Sender     `json:"sender,omitempty"`
+}
+
+func (VideoMessage) MessageType() MessageType {
+	return VideoMessageType
 }
 
 // AudioMessage https://developers.line.biz/en/reference/messaging-api/#audio-message
@@ -79,38 +182,158 @@ type AudioMessage struct {
 	Type               MessageType `json:"type"`
 	OriginalContentURL string      `json:"originalContentUrl"`
 	Duration           int         `json:"duration"` // Duration is typically represented in milliseconds
+	QuickReply         *QuickReply `json:"quickReply,omitempty"`
+	Sender             *Sender     `json:"sender,omitempty"`
+}
+
+func (AudioMessage) MessageType() MessageType {
+	return AudioMessageType
 }
 
 // LocationMessage https://developers.line.biz/en/reference/messaging-api/#location-message
 type LocationMessage struct {
-	Type      MessageType `json:"type"`
-	Title     string      `json:"title"`
-	Address   string      `json:"address"`
-	Latitude  float64     `json:"latitude"`
-	Longitude float64     `json:"longitude"`
+	Type       MessageType `json:"type"`
+	Title      string      `json:"title"`
+	Address    string      `json:"address"`
+	Latitude   float64     `json:"latitude"`
+	Longitude  float64     `json:"longitude"`
+	QuickReply *QuickReply `json:"quickReply,omitempty"`
+	Sender     *Sender     `json:"sender,omitempty"`
+}
+
+func (LocationMessage) MessageType() MessageType {
+	return LocationMessageType
 }
 
 // ImagemapMessage https://developers.line.biz/en/reference/messaging-api/#imagemap-message
+type ImagemapMessage struct {
+	Type       MessageType      `json:"type"`
+	BaseURL    string           `json:"baseUrl"`
+	AltText    string           `json:"altText"`
+	BaseSize   ImagemapBaseSize `json:"baseSize"`
+	Video      *ImagemapVideo   `json:"video,omitempty"`
+	Actions    []ImagemapAction `json:"actions"`
+	QuickReply *QuickReply      `json:"quickReply,omitempty"`
+	Sender     *Sender          `json:"sender,omitempty"`
+}
+
+func (ImagemapMessage) MessageType() MessageType {
+	return ImagemapMessageType
+}
+
+type ImagemapBaseSize struct {
+	Width  int `json:"width"` // Must be 1040
+	Height int `json:"height"`
+}
+
+type ImagemapArea struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func (a ImagemapArea) within(size ImagemapBaseSize) bool {
+	return a.X >= 0 && a.Y >= 0 && a.Width > 0 && a.Height > 0 &&
+		a.X+a.Width <= size.Width && a.Y+a.Height <= size.Height
+}
+
+type ImagemapVideo struct {
+	OriginalContentURL string                `json:"originalContentUrl"`
+	PreviewImageURL    string                `json:"previewImageUrl"`
+	Area               ImagemapArea          `json:"area"`
+	ExternalLink       *ImagemapExternalLink `json:"externalLink,omitempty"`
+}
+
+type ImagemapExternalLink struct {
+	LinkURI string `json:"linkUri"`
+	Label   string `json:"label"`
+}
+
+// ImagemapAction is a *URIImagemapAction, *MessageImagemapAction or
+// *ClipboardImagemapAction. Imagemap actions are bound to an area of the
+// image and therefore differ from the Action types.
+type ImagemapAction interface {
+	ImagemapActionType() ImagemapActionType
+	ImagemapArea() ImagemapArea
+}
+
+type URIImagemapAction struct {
+	Type    ImagemapActionType `json:"type"`
+	Label   string             `json:"label,omitempty"`
+	LinkURI string             `json:"linkUri"`
+	Area    ImagemapArea       `json:"area"`
+}
+
+func (URIImagemapAction) ImagemapActionType() ImagemapActionType {
+	return URIImagemapActionType
+}
+
+func (a URIImagemapAction) ImagemapArea() ImagemapArea {
+	return a.Area
+}
+
+type MessageImagemapAction struct {
+	Type  ImagemapActionType `json:"type"`
+	Label string             `json:"label,omitempty"`
+	Text  string             `json:"text"`
+	Area  ImagemapArea       `json:"area"`
+}
+
+func (MessageImagemapAction) ImagemapActionType() ImagemapActionType {
+	return MessageImagemapActionType
+}
+
+func (a MessageImagemapAction) ImagemapArea() ImagemapArea {
+	return a.Area
+}
+
+type ClipboardImagemapAction struct {
+	Type          ImagemapActionType `json:"type"`
+	Label         string             `json:"label,omitempty"`
+	ClipboardText string             `json:"clipboardText"`
+	Area          ImagemapArea       `json:"area"`
+}
+
+func (ClipboardImagemapAction) ImagemapActionType() ImagemapActionType {
+	return ClipboardImagemapActionType
+}
+
+func (a ClipboardImagemapAction) ImagemapArea() ImagemapArea {
+	return a.Area
+}
 
 // TemplateMessage https://developers.line.biz/en/reference/messaging-api/#template-messages
-type Template interface{}
+type Template interface {
+	TemplateType() TemplateType
+}
 
 type TemplateMessage struct {
-	Type     MessageType `json:"type"`
-	AltText  string      `json:"altText"`
-	Template Template    `json:"template"`
+	Type       MessageType `json:"type"`
+	AltText    string      `json:"altText"`
+	Template   Template    `json:"template"`
+	QuickReply *QuickReply `json:"quickReply,omitempty"`
+	Sender     *Sender     `json:"sender,omitempty"`
+}
+
+func (TemplateMessage) MessageType() MessageType {
+	return TemplateMessageType
 }
 
 type ButtonTemplate struct {
-	Type                 string         `json:"type"`
-	ThumbnailImageURL    string         `json:"thumbnailImageUrl,omitempty"`
-	ImageAspectRatio     string         `json:"imageAspectRatio,omitempty"`
-	ImageSize            string         `json:"imageSize,omitempty"`
-	ImageBackgroundColor string         `json:"imageBackgroundColor,omitempty"`
-	Title                string         `json:"title"`
-	Text                 string         `json:"text"`
-	DefaultAction        *DefaultAction `json:"defaultAction,omitempty"`
-	Actions              []Action       `json:"actions"`
+	Type                 string   `json:"type"`
+	ThumbnailImageURL    string   `json:"thumbnailImageUrl,omitempty"`
+	ImageAspectRatio     string   `json:"imageAspectRatio,omitempty"`
+	ImageSize            string   `json:"imageSize,omitempty"`
+	ImageBackgroundColor string   `json:"imageBackgroundColor,omitempty"`
+	Title                string   `json:"title"`
+	Text                 string   `json:"text"`
+	DefaultAction        Action   `json:"defaultAction,omitempty"`
+	Actions              []Action `json:"actions"`
+}
+
+func (ButtonTemplate) TemplateType() TemplateType {
+	return ButtonTemplateType
 }
 
 type ConfirmTemplate struct {
@@ -119,6 +342,10 @@ type ConfirmTemplate struct {
 	Actions []Action `json:"actions"`
 }
 
+func (ConfirmTemplate) TemplateType() TemplateType {
+	return ConfirmTemplateType
+}
+
 type CarouselTemplate struct {
 	Type             string           `json:"type"`
 	Columns          []CarouselColumn `json:"columns"`
@@ -126,13 +353,17 @@ type CarouselTemplate struct {
 	ImageSize        string           `json:"imageSize"`
 }
 
+func (CarouselTemplate) TemplateType() TemplateType {
+	return CarouselTemplateType
+}
+
 type CarouselColumn struct {
-	ThumbnailImageURL    string         `json:"thumbnailImageUrl"`
-	ImageBackgroundColor string         `json:"imageBackgroundColor"`
-	Title                string         `json:"title"`
-	Text                 string         `json:"text"`
-	DefaultAction        *DefaultAction `json:"defaultAction"`
-	Actions              []Action       `json:"actions"`
+	ThumbnailImageURL    string   `json:"thumbnailImageUrl"`
+	ImageBackgroundColor string   `json:"imageBackgroundColor"`
+	Title                string   `json:"title"`
+	Text                 string   `json:"text"`
+	DefaultAction        Action   `json:"defaultAction,omitempty"`
+	Actions              []Action `json:"actions"`
 }
 
 type ImageCarouselTemplate struct {
@@ -140,21 +371,11 @@ type ImageCarouselTemplate struct {
 	Columns []ImageCarouselColumn `json:"columns"`
 }
 
+func (ImageCarouselTemplate) TemplateType() TemplateType {
+	return ImageCarouselTemplateType
+}
+
 type ImageCarouselColumn struct {
 	ImageURL string `json:"imageUrl"`
 	Action   Action `json:"action"`
 }
-
-type DefaultAction struct {
-	Type  string `json:"type"`
-	Label string `json:"label"`
-	URI   string `json:"uri"`
-}
-
-type Action struct {
-	Type  string `json:"type"`
-	Label string `json:"label,omitempty"`
-	Data  string `json:"data,omitempty"`
-	URI   string `json:"uri,omitempty"`
-	Text  string `json:"text,omitempty"`
-}
@@ -0,0 +1,104 @@
+package line
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImagemapMessage(t *testing.T) {
+	message := `{
+		"type":"imagemap",
+		"baseUrl":"https://example.com/bot/images/rm001",
+		"altText":"This is an imagemap",
+		"baseSize":{"width":1040,"height":1040},
+		"video":{
+			"originalContentUrl":"https://example.com/video.mp4",
+			"previewImageUrl":"https://example.com/video_preview.jpg",
+			"area":{"x":0,"y":0,"width":1040,"height":585},
+			"externalLink":{"linkUri":"https://example.com/see_more.html","label":"See More"}
+		},
+		"actions":[
+			{"type":"uri","linkUri":"https://example.com/","area":{"x":0,"y":586,"width":520,"height":454}},
+			{"type":"message","text":"Hello","area":{"x":520,"y":586,"width":520,"height":454}}
+		]
+	}`
+
+	msg, err := UnmarshalMessage([]byte(message))
+	require.NoError(t, err)
+
+	imagemap, ok := msg.(*ImagemapMessage)
+	require.True(t, ok)
+	assert.Equal(t, ImagemapBaseSize{Width: 1040, Height: 1040}, imagemap.BaseSize)
+	require.Len(t, imagemap.Actions, 2)
+	assert.Equal(t, &URIImagemapAction{
+		Type:    URIImagemapActionType,
+		LinkURI: "https://example.com/",
+		Area:    ImagemapArea{X: 0, Y: 586, Width: 520, Height: 454},
+	}, imagemap.Actions[0])
+	assert.Equal(t, &MessageImagemapAction{
+		Type: MessageImagemapActionType,
+		Text: "Hello",
+		Area: ImagemapArea{X: 520, Y: 586, Width: 520, Height: 454},
+	}, imagemap.Actions[1])
+	assert.NoError(t, imagemap.Validate())
+
+	b, err := json.Marshal(imagemap)
+	require.NoError(t, err)
+	assert.JSONEq(t, message, string(b))
+}
+
+func TestImagemapMessageValidate(t *testing.T) {
+	msg := ImagemapMessage{
+		Type:     ImagemapMessageType,
+		BaseURL:  "https://example.com/bot/images/rm001",
+		AltText:  "This is an imagemap",
+		BaseSize: ImagemapBaseSize{Width: 1040, Height: 700},
+		Actions: []ImagemapAction{
+			URIImagemapAction{Type: URIImagemapActionType, LinkURI: "https://example.com/", Area: ImagemapArea{Width: 1040, Height: 700}},
+			MessageImagemapAction{Type: MessageImagemapActionType, Text: "Hello", Area: ImagemapArea{X: 520, Y: 300, Width: 521, Height: 400}},
+		},
+	}
+	var verr *ValidationError
+	require.ErrorAs(t, msg.Validate(), &verr)
+	require.Len(t, verr.Details, 1)
+	assert.Equal(t, "actions[1].area", verr.Details[0].Property)
+
+	msg.Actions = msg.Actions[:1]
+	msg.Video = &ImagemapVideo{
+		OriginalContentURL: "https://example.com/video.mp4",
+		PreviewImageURL:    "https://example.com/video_preview.jpg",
+		Area:               ImagemapArea{Y: 200, Width: 1040, Height: 585},
+	}
+	require.ErrorAs(t, msg.Validate(), &verr)
+	require.Len(t, verr.Details, 1)
+	assert.Equal(t, "video.area", verr.Details[0].Property)
+}
+
+func TestQuickReplyAndSender(t *testing.T) {
+	msg := NewTextMessage("Select your favorite food category or send me your location!").
+		WithSender(&Sender{Name: "Cony", IconURL: "https://example.com/cony.png"}).
+		WithQuickReply(NewQuickReply(
+			NewQuickReplyItem(NewMessageAction("Sushi", "Sushi")).WithImageURL("https://example.com/sushi.png"),
+			NewQuickReplyItem(NewLocationAction("Send location")),
+		))
+
+	b, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type":"text",
+		"text":"Select your favorite food category or send me your location!",
+		"quoteToken":"",
+		"sender":{"name":"Cony","iconUrl":"https://example.com/cony.png"},
+		"quickReply":{"items":[
+			{"type":"action","imageUrl":"https://example.com/sushi.png","action":{"type":"message","label":"Sushi","text":"Sushi"}},
+			{"type":"action","action":{"type":"location","label":"Send location"}}
+		]}
+	}`, string(b))
+
+	decoded, err := UnmarshalMessage(b)
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded)
+}
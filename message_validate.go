@@ -0,0 +1,582 @@
+package line
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf16"
+)
+
+// Limits documented in https://developers.line.biz/en/reference/messaging-api/#message-objects
+const (
+	MaxMessagesPerRequest = 5
+
+	maxTextLength          = 5000
+	maxEmojis              = 20
+	maxURLLength           = 2000
+	maxAltTextLength       = 400
+	maxImagemapAltText     = 1500
+	maxFlexAltText         = 1500
+	maxImagemapActions     = 50
+	imagemapBaseWidth      = 1040
+	maxTemplateTitle       = 40
+	maxButtonsText         = 160
+	maxButtonsTextWithHead = 60
+	maxButtonsActions      = 4
+	maxConfirmText         = 240
+	confirmActions         = 2
+	maxCarouselColumns     = 10
+	maxCarouselText        = 120
+	maxCarouselTextHead    = 60
+	maxCarouselActions     = 3
+	maxFlexCarouselBubbles = 12
+	maxActionLabel         = 20
+	maxImageCarouselLabel  = 12
+	maxFlexActionLabel     = 40
+	maxActionData          = 300
+	maxActionText          = 300
+	maxClipboardText       = 1000
+	maxSenderName          = 20
+	maxLocationText        = 100
+	maxTrackingIDLength    = 100
+)
+
+// ValidationError is returned by the Validate methods. Each detail names the
+// offending property with a JSON path such as "messages[0].text", matching the
+// details LINE returns for a 400 Bad Request.
+type ValidationError struct {
+	Details []ErrorDetail
+}
+
+func (e *ValidationError) Error() string {
+	details := make([]string, len(e.Details))
+	for i, d := range e.Details {
+		details[i] = fmt.Sprintf("%s: %s", d.Property, d.Message)
+	}
+	return "line: invalid request: " + strings.Join(details, "; ")
+}
+
+type validator struct {
+	details []ErrorDetail
+}
+
+func (v *validator) addf(property, format string, args ...any) {
+	v.details = append(v.details, ErrorDetail{Message: fmt.Sprintf(format, args...), Property: property})
+}
+
+func (v *validator) err() error {
+	if len(v.details) == 0 {
+		return nil
+	}
+	return &ValidationError{Details: v.details}
+}
+
+func (v *validator) required(property, value string) {
+	if value == "" {
+		v.addf(property, "must be specified")
+	}
+}
+
+func (v *validator) maxLength(property, value string, limit int) {
+	if n := textLength(value); n > limit {
+		v.addf(property, "must not exceed %d characters, got %d", limit, n)
+	}
+}
+
+func (v *validator) text(property, value string, limit int) {
+	v.required(property, value)
+	v.maxLength(property, value, limit)
+}
+
+func (v *validator) httpsURL(property, value string) {
+	if value == "" {
+		return
+	}
+	v.maxLength(property, value, maxURLLength)
+	if u, err := url.Parse(value); err != nil || u.Scheme != "https" || u.Host == "" {
+		v.addf(property, "must be an HTTPS URL")
+	}
+}
+
+func (v *validator) requiredHTTPSURL(property, value string) {
+	v.required(property, value)
+	v.httpsURL(property, value)
+}
+
+// actionURI is requiredHTTPSURL, except that it also accepts the line: and
+// tel: schemes a URI action may open.
+func (v *validator) actionURI(property, value string) {
+	if u, err := url.Parse(value); err == nil && (u.Scheme == "line" || u.Scheme == "tel") {
+		return
+	}
+	v.requiredHTTPSURL(property, value)
+}
+
+func (v *validator) count(property string, n, minItems, maxItems int) {
+	switch {
+	case n < minItems:
+		v.addf(property, "must contain at least %d items, got %d", minItems, n)
+	case n > maxItems:
+		v.addf(property, "must not contain more than %d items, got %d", maxItems, n)
+	}
+}
+
+// textLength counts characters the way LINE does, in UTF-16 code units.
+func textLength(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+func joinPath(path, property string) string {
+	if path == "" {
+		return property
+	}
+	if strings.HasPrefix(property, "[") {
+		return path + property
+	}
+	return path + "." + property
+}
+
+func indexPath(path, property string, i int) string {
+	return joinPath(path, fmt.Sprintf("%s[%d]", property, i))
+}
+
+// validatable is implemented by the types that know their own constraints.
+type validatable interface {
+	validate(v *validator, path string)
+}
+
+// validatableAction is implemented by the action types. The label limit
+// depends on where the action is used.
+type validatableAction interface {
+	validate(v *validator, path string, maxLabel int)
+}
+
+func validateValue(v *validator, path string, value any) {
+	if val, ok := value.(validatable); ok {
+		val.validate(v, path)
+	}
+}
+
+func validate(value validatable) error {
+	v := &validator{}
+	value.validate(v, "")
+	return v.err()
+}
+
+func validateMessages(v *validator, messages Messages) {
+	v.count("messages", len(messages), 1, MaxMessagesPerRequest)
+	for i, msg := range messages {
+		p := indexPath("", "messages", i)
+		if msg == nil {
+			v.addf(p, "must be specified")
+			continue
+		}
+		validateValue(v, p, msg)
+	}
+}
+
+func validateCommon(v *validator, path string, quickReply *QuickReply, sender *Sender) {
+	if quickReply != nil {
+		quickReply.validate(v, joinPath(path, "quickReply"))
+	}
+	if sender != nil {
+		sender.validate(v, joinPath(path, "sender"))
+	}
+}
+
+func validateAction(v *validator, path string, action Action, maxLabel int) {
+	if action == nil {
+		v.addf(path, "must be specified")
+		return
+	}
+	if a, ok := action.(validatableAction); ok {
+		a.validate(v, path, maxLabel)
+	}
+}
+
+func (a PostbackAction) validate(v *validator, path string, maxLabel int) {
+	v.maxLength(joinPath(path, "label"), a.Label, maxLabel)
+	v.text(joinPath(path, "data"), a.Data, maxActionData)
+	v.maxLength(joinPath(path, "displayText"), a.DisplayText, maxActionText)
+}
+
+func (a MessageAction) validate(v *validator, path string, maxLabel int) {
+	v.maxLength(joinPath(path, "label"), a.Label, maxLabel)
+	v.text(joinPath(path, "text"), a.Text, maxActionText)
+}
+
+func (a URIAction) validate(v *validator, path string, maxLabel int) {
+	v.maxLength(joinPath(path, "label"), a.Label, maxLabel)
+	v.actionURI(joinPath(path, "uri"), a.URI)
+	if a.AltURI != nil {
+		v.actionURI(joinPath(path, "altUri.desktop"), a.AltURI.Desktop)
+	}
+}
+
+func (a DatetimePickerAction) validate(v *validator, path string, maxLabel int) {
+	v.maxLength(joinPath(path, "label"), a.Label, maxLabel)
+	v.text(joinPath(path, "data"), a.Data, maxActionData)
+	switch a.Mode {
+	case DatetimePickerModeDate, DatetimePickerModeTime, DatetimePickerModeDatetime:
+	default:
+		v.addf(joinPath(path, "mode"), "must be one of date, time or datetime, got %q", a.Mode)
+	}
+}
+
+func (a CameraAction) validate(v *validator, path string, maxLabel int) {
+	v.text(joinPath(path, "label"), a.Label, maxLabel)
+}
+
+func (a CameraRollAction) validate(v *validator, path string, maxLabel int) {
+	v.text(joinPath(path, "label"), a.Label, maxLabel)
+}
+
+func (a LocationAction) validate(v *validator, path string, maxLabel int) {
+	v.text(joinPath(path, "label"), a.Label, maxLabel)
+}
+
+func (a RichMenuSwitchAction) validate(v *validator, path string, maxLabel int) {
+	v.maxLength(joinPath(path, "label"), a.Label, maxLabel)
+	v.required(joinPath(path, "richMenuAliasId"), a.RichMenuAliasID)
+	v.text(joinPath(path, "data"), a.Data, maxActionData)
+}
+
+func (a ClipboardAction) validate(v *validator, path string, maxLabel int) {
+	v.maxLength(joinPath(path, "label"), a.Label, maxLabel)
+	v.text(joinPath(path, "clipboardText"), a.ClipboardText, maxClipboardText)
+}
+
+// Validate checks the documented constraints of the request without calling the API.
+func (o MessagePushOptions) Validate() error { return validate(o) }
+
+func (o MessagePushOptions) validate(v *validator, _ string) {
+	v.required("to", o.To)
+	validateMessages(v, o.Messages)
+}
+
+// Validate checks the documented constraints of the request without calling the API.
+func (o MessageReplyOptions) Validate() error { return validate(o) }
+
+func (o MessageReplyOptions) validate(v *validator, _ string) {
+	v.required("replyToken", o.ReplyToken)
+	validateMessages(v, o.Messages)
+}
+
+// Validate checks the documented constraints of the request without calling the API.
+func (o MessageMulticastOptions) Validate() error { return validate(o) }
+
+func (o MessageMulticastOptions) validate(v *validator, _ string) {
+	v.count("to", len(o.To), 1, MaxMulticastRecipients)
+	validateMessages(v, o.Messages)
+}
+
+// Validate checks the documented constraints of the request without calling the API.
+func (o MessageNarrowcastOptions) Validate() error { return validate(o) }
+
+func (o MessageNarrowcastOptions) validate(v *validator, _ string) {
+	validateMessages(v, o.Messages)
+}
+
+// Validate checks the documented constraints of the request without calling the API.
+func (o MessageBroadcastOptions) Validate() error { return validate(o) }
+
+func (o MessageBroadcastOptions) validate(v *validator, _ string) {
+	validateMessages(v, o.Messages)
+}
+
+func (q QuickReply) validate(v *validator, path string) {
+	v.count(joinPath(path, "items"), len(q.Items), 1, MaxQuickReplyItems)
+	for i, item := range q.Items {
+		p := indexPath(path, "items", i)
+		v.httpsURL(joinPath(p, "imageUrl"), item.ImageURL)
+		validateAction(v, joinPath(p, "action"), item.Action, maxActionLabel)
+	}
+}
+
+func (s Sender) validate(v *validator, path string) {
+	v.maxLength(joinPath(path, "name"), s.Name, maxSenderName)
+	v.httpsURL(joinPath(path, "iconUrl"), s.IconURL)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m TextMessage) Validate() error { return validate(m) }
+
+func (m TextMessage) validate(v *validator, path string) {
+	v.text(joinPath(path, "text"), m.Text, maxTextLength)
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m EmojiMessage) Validate() error { return validate(m) }
+
+func (m EmojiMessage) validate(v *validator, path string) {
+	v.text(joinPath(path, "text"), m.Text, maxTextLength)
+	v.count(joinPath(path, "emojis"), len(m.Emojis), 0, maxEmojis)
+
+	text := utf16.Encode([]rune(m.Text))
+	for i, emoji := range m.Emojis {
+		p := indexPath(path, "emojis", i)
+		if emoji.Index < 0 || emoji.Index >= len(text) {
+			v.addf(joinPath(p, "index"), "must be within the text, got %d", emoji.Index)
+		} else if text[emoji.Index] != '$' {
+			v.addf(joinPath(p, "index"), "must point to a $ character, got %d", emoji.Index)
+		}
+		v.required(joinPath(p, "productId"), emoji.ProductID)
+		v.required(joinPath(p, "emojiId"), emoji.EmojiID)
+	}
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m StickerMessage) Validate() error { return validate(m) }
+
+func (m StickerMessage) validate(v *validator, path string) {
+	v.required(joinPath(path, "packageId"), m.PackageID)
+	v.required(joinPath(path, "stickerId"), m.StickerID)
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m ImageMessage) Validate() error { return validate(m) }
+
+func (m ImageMessage) validate(v *validator, path string) {
+	v.requiredHTTPSURL(joinPath(path, "originalContentUrl"), m.OriginalContentURL)
+	v.requiredHTTPSURL(joinPath(path, "previewImageUrl"), m.PreviewImageURL)
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m VideoMessage) Validate() error { return validate(m) }
+
+func (m VideoMessage) validate(v *validator, path string) {
+	v.requiredHTTPSURL(joinPath(path, "originalContentUrl"), m.OriginalContentURL)
+	v.requiredHTTPSURL(joinPath(path, "previewImageUrl"), m.PreviewImageURL)
+	v.maxLength(joinPath(path, "trackingId"), m.TrackingID, maxTrackingIDLength)
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m AudioMessage) Validate() error { return validate(m) }
+
+func (m AudioMessage) validate(v *validator, path string) {
+	v.requiredHTTPSURL(joinPath(path, "originalContentUrl"), m.OriginalContentURL)
+	if m.Duration <= 0 {
+		v.addf(joinPath(path, "duration"), "must be a positive number of milliseconds")
+	}
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m LocationMessage) Validate() error { return validate(m) }
+
+func (m LocationMessage) validate(v *validator, path string) {
+	v.text(joinPath(path, "title"), m.Title, maxLocationText)
+	v.text(joinPath(path, "address"), m.Address, maxLocationText)
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling
+// the API, including that the video and every action area lie within the
+// base size.
+func (m ImagemapMessage) Validate() error { return validate(m) }
+
+func (m ImagemapMessage) validate(v *validator, path string) {
+	v.requiredHTTPSURL(joinPath(path, "baseUrl"), m.BaseURL)
+	v.text(joinPath(path, "altText"), m.AltText, maxImagemapAltText)
+	if m.BaseSize.Width != imagemapBaseWidth {
+		v.addf(joinPath(path, "baseSize.width"), "must be %d, got %d", imagemapBaseWidth, m.BaseSize.Width)
+	}
+	if m.BaseSize.Height <= 0 {
+		v.addf(joinPath(path, "baseSize.height"), "must be positive, got %d", m.BaseSize.Height)
+	}
+
+	if m.Video != nil {
+		p := joinPath(path, "video")
+		v.requiredHTTPSURL(joinPath(p, "originalContentUrl"), m.Video.OriginalContentURL)
+		v.requiredHTTPSURL(joinPath(p, "previewImageUrl"), m.Video.PreviewImageURL)
+		if !m.Video.Area.within(m.BaseSize) {
+			v.addf(joinPath(p, "area"), "%+v must lie within the base size %+v", m.Video.Area, m.BaseSize)
+		}
+	}
+
+	v.count(joinPath(path, "actions"), len(m.Actions), 1, maxImagemapActions)
+	for i, action := range m.Actions {
+		p := indexPath(path, "actions", i)
+		if action == nil {
+			v.addf(p, "must be specified")
+			continue
+		}
+		if area := action.ImagemapArea(); !area.within(m.BaseSize) {
+			v.addf(joinPath(p, "area"), "%+v must lie within the base size %+v", area, m.BaseSize)
+		}
+	}
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m TemplateMessage) Validate() error { return validate(m) }
+
+func (m TemplateMessage) validate(v *validator, path string) {
+	v.text(joinPath(path, "altText"), m.AltText, maxAltTextLength)
+	if m.Template == nil {
+		v.addf(joinPath(path, "template"), "must be specified")
+	} else {
+		validateValue(v, joinPath(path, "template"), m.Template)
+	}
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+func (t ButtonTemplate) validate(v *validator, path string) {
+	v.httpsURL(joinPath(path, "thumbnailImageUrl"), t.ThumbnailImageURL)
+	v.maxLength(joinPath(path, "title"), t.Title, maxTemplateTitle)
+	maxText := maxButtonsText
+	if t.ThumbnailImageURL != "" || t.Title != "" {
+		maxText = maxButtonsTextWithHead
+	}
+	v.text(joinPath(path, "text"), t.Text, maxText)
+	if t.DefaultAction != nil {
+		validateAction(v, joinPath(path, "defaultAction"), t.DefaultAction, maxActionLabel)
+	}
+	validateActions(v, path, t.Actions, 1, maxButtonsActions)
+}
+
+func (t ConfirmTemplate) validate(v *validator, path string) {
+	v.text(joinPath(path, "text"), t.Text, maxConfirmText)
+	validateActions(v, path, t.Actions, confirmActions, confirmActions)
+}
+
+func (t CarouselTemplate) validate(v *validator, path string) {
+	v.count(joinPath(path, "columns"), len(t.Columns), 1, maxCarouselColumns)
+	for i, column := range t.Columns {
+		p := indexPath(path, "columns", i)
+		v.httpsURL(joinPath(p, "thumbnailImageUrl"), column.ThumbnailImageURL)
+		v.maxLength(joinPath(p, "title"), column.Title, maxTemplateTitle)
+		maxText := maxCarouselText
+		if column.ThumbnailImageURL != "" || column.Title != "" {
+			maxText = maxCarouselTextHead
+		}
+		v.text(joinPath(p, "text"), column.Text, maxText)
+		if column.DefaultAction != nil {
+			validateAction(v, joinPath(p, "defaultAction"), column.DefaultAction, maxActionLabel)
+		}
+		validateActions(v, p, column.Actions, 1, maxCarouselActions)
+		if i > 0 && len(column.Actions) != len(t.Columns[0].Actions) {
+			v.addf(joinPath(p, "actions"), "must contain the same number of actions as columns[0], got %d, want %d",
+				len(column.Actions), len(t.Columns[0].Actions))
+		}
+	}
+}
+
+func (t ImageCarouselTemplate) validate(v *validator, path string) {
+	v.count(joinPath(path, "columns"), len(t.Columns), 1, maxCarouselColumns)
+	for i, column := range t.Columns {
+		p := indexPath(path, "columns", i)
+		v.requiredHTTPSURL(joinPath(p, "imageUrl"), column.ImageURL)
+		validateAction(v, joinPath(p, "action"), column.Action, maxImageCarouselLabel)
+	}
+}
+
+func validateActions(v *validator, path string, actions []Action, minActions, maxActions int) {
+	v.count(joinPath(path, "actions"), len(actions), minActions, maxActions)
+	for i, action := range actions {
+		validateAction(v, indexPath(path, "actions", i), action, maxActionLabel)
+	}
+}
+
+// Validate checks the documented constraints of the message without calling the API.
+func (m FlexMessage) Validate() error { return validate(m) }
+
+func (m FlexMessage) validate(v *validator, path string) {
+	v.text(joinPath(path, "altText"), m.AltText, maxFlexAltText)
+	if m.Contents == nil {
+		v.addf(joinPath(path, "contents"), "must be specified")
+	} else {
+		validateValue(v, joinPath(path, "contents"), m.Contents)
+	}
+	validateCommon(v, path, m.QuickReply, m.Sender)
+}
+
+func (c CarouselContainer) validate(v *validator, path string) {
+	v.count(joinPath(path, "contents"), len(c.Contents), 1, maxFlexCarouselBubbles)
+	for i, bubble := range c.Contents {
+		p := indexPath(path, "contents", i)
+		if bubble == nil {
+			v.addf(p, "must be specified")
+			continue
+		}
+		bubble.validate(v, p)
+	}
+}
+
+func (c BubbleContainer) validate(v *validator, path string) {
+	if c.Header != nil {
+		c.Header.validate(v, joinPath(path, "header"))
+	}
+	if c.Hero != nil {
+		validateFlexComponent(v, joinPath(path, "hero"), c.Hero)
+	}
+	if c.Body != nil {
+		c.Body.validate(v, joinPath(path, "body"))
+	}
+	if c.Footer != nil {
+		c.Footer.validate(v, joinPath(path, "footer"))
+	}
+	validateFlexAction(v, path, c.Action)
+}
+
+func validateFlexComponent(v *validator, path string, component FlexComponent) {
+	if component == nil {
+		v.addf(path, "must be specified")
+		return
+	}
+	validateValue(v, path, component)
+}
+
+// validateFlexAction validates the optional action of a container or component.
+func validateFlexAction(v *validator, path string, action Action) {
+	if action != nil {
+		validateAction(v, joinPath(path, "action"), action, maxFlexActionLabel)
+	}
+}
+
+func (c BoxComponent) validate(v *validator, path string) {
+	for i, component := range c.Contents {
+		validateFlexComponent(v, indexPath(path, "contents", i), component)
+	}
+	validateFlexAction(v, path, c.Action)
+}
+
+func (c ButtonComponent) validate(v *validator, path string) {
+	validateAction(v, joinPath(path, "action"), c.Action, maxFlexActionLabel)
+}
+
+func (c ImageComponent) validate(v *validator, path string) {
+	v.requiredHTTPSURL(joinPath(path, "url"), c.URL)
+	validateFlexAction(v, path, c.Action)
+}
+
+func (c IconComponent) validate(v *validator, path string) {
+	v.requiredHTTPSURL(joinPath(path, "url"), c.URL)
+}
+
+func (c TextComponent) validate(v *validator, path string) {
+	if c.Text == "" && len(c.Contents) == 0 {
+		v.addf(joinPath(path, "text"), "must be specified")
+	}
+	for i, span := range c.Contents {
+		p := indexPath(path, "contents", i)
+		if span == nil {
+			v.addf(p, "must be specified")
+			continue
+		}
+		v.required(joinPath(p, "text"), span.Text)
+	}
+	validateFlexAction(v, path, c.Action)
+}
+
+func (c VideoComponent) validate(v *validator, path string) {
+	v.requiredHTTPSURL(joinPath(path, "url"), c.URL)
+	v.requiredHTTPSURL(joinPath(path, "previewUrl"), c.PreviewURL)
+	validateFlexComponent(v, joinPath(path, "altContent"), c.AltContent)
+	validateFlexAction(v, path, c.Action)
+}
@@ -0,0 +1,158 @@
+package line
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagePushOptionsValidate(t *testing.T) {
+	valid := MessagePushOptions{
+		To: "U1234567890",
+		Messages: Messages{
+			NewTextMessage("Hello, World!"),
+			NewImageMessage("https://example.com/original.jpg", "https://example.com/preview.jpg"),
+		},
+	}
+	assert.NoError(t, valid.Validate())
+
+	flex := MessagePushOptions{To: "U1", Messages: Messages{
+		NewFlexMessage("flex", NewFlexBubble().
+			WithHero(NewFlexImage("https://example.com/hero.jpg")).
+			WithFooter(NewFlexBox(FlexBoxLayoutVertical,
+				NewFlexButton(NewURIAction("Call the cafe for a reservation", "tel:0312345678")),
+				NewFlexButton(NewURIAction("Open", "https://example.com")),
+			))),
+	}}
+	assert.NoError(t, flex.Validate())
+
+	for _, n := range []int{401, 1500} {
+		flex := MessagePushOptions{To: "U1", Messages: Messages{
+			NewFlexMessage(strings.Repeat("a", n), NewFlexBubble().WithBody(NewFlexBox(FlexBoxLayoutVertical, NewFlexText("hi")))),
+		}}
+		assert.NoError(t, flex.Validate(), "flex altText of %d characters", n)
+	}
+
+	tests := []struct {
+		name     string
+		opt      MessagePushOptions
+		expected []ErrorDetail
+	}{
+		{
+			name: "too many messages",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewTextMessage("1"), NewTextMessage("2"), NewTextMessage("3"),
+				NewTextMessage("4"), NewTextMessage("5"), NewTextMessage("6"),
+			}},
+			expected: []ErrorDetail{{Property: "messages", Message: "must not contain more than 5 items, got 6"}},
+		},
+		{
+			name: "text too long",
+			opt:  MessagePushOptions{To: "U1", Messages: Messages{NewTextMessage(strings.Repeat("a", 5001))}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].text", Message: "must not exceed 5000 characters, got 5001"},
+			},
+		},
+		{
+			name: "emoji index",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewEmojiMessage("$ LINE emoji", Emoji{Index: 0, ProductID: "p", EmojiID: "001"}, Emoji{Index: 2, ProductID: "p", EmojiID: "002"}, Emoji{Index: 40, ProductID: "p", EmojiID: "003"}),
+			}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].emojis[1].index", Message: "must point to a $ character, got 2"},
+				{Property: "messages[0].emojis[2].index", Message: "must be within the text, got 40"},
+			},
+		},
+		{
+			name: "http url",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewImageMessage("http://example.com/original.jpg", "https://example.com/preview.jpg"),
+			}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].originalContentUrl", Message: "must be an HTTPS URL"},
+			},
+		},
+		{
+			name: "template",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewTemplateMessage("", &ConfirmTemplate{Type: "confirm", Text: "Are you sure?", Actions: []Action{NewMessageAction("Yes", "yes")}}),
+				NewTemplateMessage("carousel", &CarouselTemplate{Type: "carousel", Columns: []CarouselColumn{
+					{Text: "first", Actions: []Action{NewMessageAction("Yes", "yes"), NewMessageAction("No", "no")}},
+					{Text: "second", Actions: []Action{NewMessageAction("Yes", "yes"), nil}},
+					{Text: "third", Actions: []Action{NewMessageAction("Yes", "yes")}},
+				}}),
+			}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].altText", Message: "must be specified"},
+				{Property: "messages[0].template.actions", Message: "must contain at least 2 items, got 1"},
+				{Property: "messages[1].template.columns[1].actions[1]", Message: "must be specified"},
+				{Property: "messages[1].template.columns[2].actions", Message: "must contain the same number of actions as columns[0], got 1, want 2"},
+			},
+		},
+		{
+			name: "flex alt text too long",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewFlexMessage(strings.Repeat("a", 1501), NewFlexBubble().WithBody(NewFlexBox(FlexBoxLayoutVertical, NewFlexText("hi")))),
+			}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].altText", Message: "must not exceed 1500 characters, got 1501"},
+			},
+		},
+		{
+			name: "actions",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewTemplateMessage("buttons", &ButtonTemplate{Type: "buttons", Text: "Pick one", Actions: []Action{
+					NewURIAction("Open", "http://example.com"),
+					NewPostbackAction(strings.Repeat("a", 21), ""),
+					&URIAction{Type: URIActionType, Label: "Call", URI: "tel:0312345678", AltURI: &AltURI{Desktop: "ftp://example.com"}},
+					NewDatetimePickerAction("Date", "date", ""),
+				}}),
+			}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].template.actions[0].uri", Message: "must be an HTTPS URL"},
+				{Property: "messages[0].template.actions[1].label", Message: "must not exceed 20 characters, got 21"},
+				{Property: "messages[0].template.actions[1].data", Message: "must be specified"},
+				{Property: "messages[0].template.actions[2].altUri.desktop", Message: "must be an HTTPS URL"},
+				{Property: "messages[0].template.actions[3].mode", Message: `must be one of date, time or datetime, got ""`},
+			},
+		},
+		{
+			name: "flex contents",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewFlexMessage("flex", NewFlexCarousel(
+					NewFlexBubble().
+						WithHero(NewFlexImage("http://example.com/hero.jpg")).
+						WithBody(NewFlexBox(FlexBoxLayoutVertical, NewFlexText(""), NewFlexIcon("http://example.com/icon.png"))),
+					NewFlexBubble().
+						WithFooter(NewFlexBox(FlexBoxLayoutVertical, NewFlexButton(NewURIAction("Visit", "http://example.com")))),
+				)),
+			}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].contents.contents[0].hero.url", Message: "must be an HTTPS URL"},
+				{Property: "messages[0].contents.contents[0].body.contents[0].text", Message: "must be specified"},
+				{Property: "messages[0].contents.contents[0].body.contents[1].url", Message: "must be an HTTPS URL"},
+				{Property: "messages[0].contents.contents[1].footer.contents[0].action.uri", Message: "must be an HTTPS URL"},
+			},
+		},
+		{
+			name: "quick reply",
+			opt: MessagePushOptions{To: "U1", Messages: Messages{
+				NewTextMessage("hi").WithQuickReply(NewQuickReply()).WithSender(&Sender{IconURL: "http://example.com/icon.png"}),
+			}},
+			expected: []ErrorDetail{
+				{Property: "messages[0].quickReply.items", Message: "must contain at least 1 items, got 0"},
+				{Property: "messages[0].sender.iconUrl", Message: "must be an HTTPS URL"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verr *ValidationError
+			require.ErrorAs(t, tt.opt.Validate(), &verr)
+			assert.Equal(t, tt.expected, verr.Details)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package line
+
+type (
+	RecipientType         string
+	DemographicFilterType string
+)
+
+const (
+	AudienceRecipientType   RecipientType = "audience"
+	RedeliveryRecipientType RecipientType = "redelivery"
+	OperatorRecipientType   RecipientType = "operator"
+
+	GenderDemographicFilterType             DemographicFilterType = "gender"
+	AgeDemographicFilterType                DemographicFilterType = "age"
+	AppTypeDemographicFilterType            DemographicFilterType = "appType"
+	AreaDemographicFilterType               DemographicFilterType = "area"
+	SubscriptionPeriodDemographicFilterType DemographicFilterType = "subscriptionPeriod"
+	OperatorDemographicFilterType           DemographicFilterType = "operator"
+)
+
+// Recipient https://developers.line.biz/en/reference/messaging-api/#narrowcast-recipient
+type Recipient interface{}
+
+type AudienceRecipient struct {
+	Type            RecipientType `json:"type"`
+	AudienceGroupID int64         `json:"audienceGroupId"`
+}
+
+type RedeliveryRecipient struct {
+	Type      RecipientType `json:"type"`
+	RequestID string        `json:"requestId"`
+}
+
+type OperatorRecipient struct {
+	Type RecipientType `json:"type"`
+	And  []Recipient   `json:"and,omitempty"`
+	Or   []Recipient   `json:"or,omitempty"`
+	Not  Recipient     `json:"not,omitempty"`
+}
+
+// NarrowcastFilter https://developers.line.biz/en/reference/messaging-api/#narrowcast-demographic-filter
+type NarrowcastFilter struct {
+	Demographic DemographicFilter `json:"demographic,omitempty"`
+}
+
+type DemographicFilter interface{}
+
+type GenderDemographicFilter struct {
+	Type  DemographicFilterType `json:"type"`
+	OneOf []string              `json:"oneOf,omitempty"` // male, female
+}
+
+type AgeDemographicFilter struct {
+	Type DemographicFilterType `json:"type"`
+	Gte  string                `json:"gte,omitempty"` // age_15, age_20, ... age_70
+	Lt   string                `json:"lt,omitempty"`
+}
+
+type AppTypeDemographicFilter struct {
+	Type  DemographicFilterType `json:"type"`
+	OneOf []string              `json:"oneOf,omitempty"` // ios, android
+}
+
+type AreaDemographicFilter struct {
+	Type  DemographicFilterType `json:"type"`
+	OneOf []string              `json:"oneOf,omitempty"` // jp_01, tw_01, th_01, ...
+}
+
+type SubscriptionPeriodDemographicFilter struct {
+	Type DemographicFilterType `json:"type"`
+	Gte  string                `json:"gte,omitempty"` // day_7, day_30, day_90, day_180, day_365
+	Lt   string                `json:"lt,omitempty"`
+}
+
+type OperatorDemographicFilter struct {
+	Type DemographicFilterType `json:"type"`
+	And  []DemographicFilter   `json:"and,omitempty"`
+	Or   []DemographicFilter   `json:"or,omitempty"`
+	Not  DemographicFilter     `json:"not,omitempty"`
+}
+
+// NarrowcastLimit https://developers.line.biz/en/reference/messaging-api/#send-narrowcast-message
+type NarrowcastLimit struct {
+	Max                int  `json:"max,omitempty"`
+	UpToRemainingQuota bool `json:"upToRemainingQuota,omitempty"`
+}
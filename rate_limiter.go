@@ -0,0 +1,324 @@
+package line
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitKey configures the limit of every endpoint that has no limit
+// of its own. Each such endpoint gets a separate bucket, keyed by its path
+// template, e.g. "bot/profile/{userId}".
+const DefaultRateLimitKey = "*"
+
+// endpointTemplates are the parameterized endpoints of the Messaging API. A
+// path matching one of them shares its bucket with the other IDs.
+var endpointTemplates = []string{
+	"bot/chat/{chatId}/control/acquire",
+	"bot/chat/{chatId}/control/release",
+	"bot/message/{messageId}/content",
+	"bot/message/{messageId}/content/preview",
+	"bot/message/{messageId}/content/transcoding",
+	"bot/profile/{userId}",
+	"bot/richmenu/{richMenuId}",
+	"bot/richmenu/{richMenuId}/content",
+	"bot/richmenu/alias/{richMenuAliasId}",
+	"bot/user/{userId}/richmenu",
+	"bot/user/{userId}/richmenu/{richMenuId}",
+	"bot/user/all/richmenu/{richMenuId}",
+}
+
+// lineID matches the user, group and room IDs and the numeric IDs in paths
+// that are not among endpointTemplates.
+var lineID = regexp.MustCompile(`^([UCR][0-9a-f]{32}|[0-9]+)$`)
+
+// RateLimit allows Requests requests every Per, with bursts of up to Burst
+// requests. Burst defaults to Requests. A limit applies to the endpoint and
+// the paths below it, unless Exact is set.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+	Exact    bool
+}
+
+// DefaultRateLimits returns the limits documented in
+// https://developers.line.biz/en/reference/messaging-api/#rate-limits
+func DefaultRateLimits() map[string]RateLimit {
+	return map[string]RateLimit{
+		"bot/message/narrowcast": {Requests: 60, Per: time.Hour},
+		"bot/message/broadcast":  {Requests: 60, Per: time.Hour},
+		"bot/richmenu":           {Requests: 100, Per: time.Hour, Exact: true},
+		DefaultRateLimitKey:      {Requests: 2000, Per: time.Second},
+	}
+}
+
+// RateLimiterState is a snapshot of a single endpoint limiter, e.g. for metrics.
+type RateLimiterState struct {
+	Endpoint     string
+	Limit        RateLimit
+	Tokens       float64
+	BlockedUntil time.Time
+}
+
+// RateLimiter is a set of token buckets keyed by endpoint path relative to
+// the API version, e.g. "bot/message/push". A path uses the bucket of the
+// longest configured endpoint it starts with, so "bot/richmenu" also limits
+// "bot/richmenu/{richMenuId}/content". Any other endpoint gets its own bucket
+// with the DefaultRateLimitKey limit, if one is configured. It is safe for
+// concurrent use.
+type RateLimiter struct {
+	mu       sync.Mutex
+	buckets  map[string]*tokenBucket
+	fallback *RateLimit
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimiter returns a limiter enforcing the given limits. Pass
+// DefaultRateLimits() for LINE's documented limits.
+func NewRateLimiter(limits map[string]RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		buckets: make(map[string]*tokenBucket, len(limits)),
+		now:     time.Now,
+		sleep:   sleep,
+	}
+	for endpoint, limit := range limits {
+		if limit.Requests <= 0 || limit.Per <= 0 {
+			continue
+		}
+		if limit.Burst <= 0 {
+			limit.Burst = limit.Requests
+		}
+		if endpoint == DefaultRateLimitKey {
+			l.fallback = &limit
+			continue
+		}
+		l.buckets[strings.Trim(endpoint, "/")] = newTokenBucket(limit, l.now())
+	}
+	return l
+}
+
+// Wait blocks until a request to path may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	_, b := l.bucket(path)
+	if b == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		delay := b.take(l.now())
+		l.mu.Unlock()
+		if delay <= 0 {
+			return nil
+		}
+
+		if err := l.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// BlockUntil holds back every request to the endpoint of path until t, e.g.
+// after a 429 response with a Retry-After header.
+func (l *RateLimiter) BlockUntil(path string, t time.Time) {
+	_, b := l.bucket(path)
+	if b == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(b.blockedUntil) {
+		b.blockedUntil = t
+	}
+}
+
+// State returns a snapshot of every endpoint limiter, sorted by endpoint.
+func (l *RateLimiter) State() []RateLimiterState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	states := make([]RateLimiterState, 0, len(l.buckets))
+	for endpoint, b := range l.buckets {
+		b.refill(now)
+		states = append(states, RateLimiterState{
+			Endpoint:     endpoint,
+			Limit:        b.limit,
+			Tokens:       b.tokens,
+			BlockedUntil: b.blockedUntil,
+		})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Endpoint < states[j].Endpoint
+	})
+	return states
+}
+
+// bucket returns the bucket limiting path and the endpoint it is keyed by,
+// creating the bucket of an endpoint without a limit of its own on first use.
+func (l *RateLimiter) bucket(path string) (string, *tokenBucket) {
+	path = strings.Trim(path, "/")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var (
+		endpoint string
+		bucket   *tokenBucket
+	)
+	for e, b := range l.buckets {
+		if b.fallback || len(e) <= len(endpoint) {
+			continue
+		}
+		if path == e || !b.limit.Exact && strings.HasPrefix(path, e+"/") {
+			endpoint, bucket = e, b
+		}
+	}
+	if bucket != nil || l.fallback == nil {
+		return endpoint, bucket
+	}
+
+	endpoint = endpointTemplate(path)
+	if b, ok := l.buckets[endpoint]; ok {
+		return endpoint, b
+	}
+	bucket = newTokenBucket(*l.fallback, l.now())
+	bucket.fallback = true
+	l.buckets[endpoint] = bucket
+	return endpoint, bucket
+}
+
+// endpointTemplate replaces the IDs in path by the parameters of its
+// endpoint, so that every ID shares one bucket.
+func endpointTemplate(path string) string {
+	segments := strings.Split(path, "/")
+
+	var (
+		template string
+		literals = -1
+	)
+	for _, t := range endpointTemplates {
+		params := strings.Split(t, "/")
+		if len(params) != len(segments) {
+			continue
+		}
+		n := 0
+		for i, param := range params {
+			if strings.HasPrefix(param, "{") {
+				continue
+			}
+			if param != segments[i] {
+				n = -1
+				break
+			}
+			n++
+		}
+		// Prefer literal segments, e.g. "bot/user/all/richmenu/{richMenuId}"
+		// over "bot/user/{userId}/richmenu/{richMenuId}".
+		if n > literals {
+			template, literals = t, n
+		}
+	}
+	if template != "" {
+		return template
+	}
+
+	for i, segment := range segments {
+		if lineID.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// handleResponse blocks the endpoint of path, and no other, for the duration
+// given by Retry-After when LINE answers 429 Too Many Requests.
+func (l *RateLimiter) handleResponse(path string, resp *http.Response) {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	now := l.now()
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+		l.BlockUntil(path, now.Add(d))
+	}
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+type tokenBucket struct {
+	limit        RateLimit
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+
+	// fallback is set for a bucket created from the DefaultRateLimitKey limit.
+	fallback bool
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		rate := float64(b.limit.Requests) / float64(b.limit.Per)
+		b.tokens = min(b.tokens+float64(elapsed)*rate, float64(b.limit.Burst))
+		b.last = now
+	}
+}
+
+// take consumes a token and returns 0, or returns how long to wait before
+// trying again.
+func (b *tokenBucket) take(now time.Time) time.Duration {
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	rate := float64(b.limit.Requests) / float64(b.limit.Per)
+	return time.Duration(math.Ceil((1 - b.tokens) / rate))
+}
@@ -0,0 +1,157 @@
+package line
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock replaces the clock of l with one that only advances when Wait
+// sleeps, and returns the total time slept.
+func fakeClock(l *RateLimiter) *time.Duration {
+	now := time.Unix(1700000000, 0)
+	var slept time.Duration
+	l.now = func() time.Time { return now }
+	for _, b := range l.buckets {
+		b.last = now
+	}
+	l.sleep = func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		now = now.Add(d)
+		slept += d
+		return nil
+	}
+	return &slept
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(map[string]RateLimit{
+		"bot/message/push": {Requests: 20, Per: time.Second, Burst: 2},
+		"bot/richmenu":     {Requests: 1, Per: time.Hour},
+	})
+	slept := fakeClock(l)
+
+	for range 3 {
+		require.NoError(t, l.Wait(context.Background(), "bot/message/push"))
+	}
+	assert.Equal(t, 50*time.Millisecond, *slept)
+
+	// Paths without a limit are not held back.
+	require.NoError(t, l.Wait(context.Background(), "bot/profile/U123"))
+
+	require.NoError(t, l.Wait(context.Background(), "bot/richmenu"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.Wait(ctx, "bot/richmenu/richmenu-123/content"), context.Canceled)
+
+	states := l.State()
+	require.Len(t, states, 2)
+	assert.Equal(t, "bot/message/push", states[0].Endpoint)
+	assert.Equal(t, "bot/richmenu", states[1].Endpoint)
+	assert.Less(t, states[1].Tokens, 1.0)
+
+	*slept = 0
+	require.NoError(t, l.Wait(context.Background(), "bot/richmenu/richmenu-123/content"))
+	assert.Equal(t, time.Hour, *slept)
+}
+
+func TestRateLimiterDefault(t *testing.T) {
+	l := NewRateLimiter(map[string]RateLimit{
+		DefaultRateLimitKey: {Requests: 1, Per: time.Second},
+	})
+	slept := fakeClock(l)
+
+	// Every endpoint has a budget of its own, shared by all of its IDs.
+	for _, path := range []string{
+		"bot/message/push",
+		"bot/profile/U4af4980629" + strings.Repeat("0", 22),
+		"bot/user/all/richmenu/richmenu-123",
+		"bot/user/U1/richmenu/richmenu-123",
+		"bot/group/C4af4980629" + strings.Repeat("0", 22) + "/summary",
+	} {
+		require.NoError(t, l.Wait(context.Background(), path))
+	}
+	assert.Zero(t, *slept)
+
+	require.NoError(t, l.Wait(context.Background(), "bot/profile/U2"))
+	assert.Equal(t, time.Second, *slept)
+
+	var endpoints []string
+	for _, s := range l.State() {
+		endpoints = append(endpoints, s.Endpoint)
+	}
+	assert.Equal(t, []string{
+		"bot/group/{id}/summary",
+		"bot/message/push",
+		"bot/profile/{userId}",
+		"bot/user/all/richmenu/{richMenuId}",
+		"bot/user/{userId}/richmenu/{richMenuId}",
+	}, endpoints)
+}
+
+func TestRateLimiterExact(t *testing.T) {
+	l := NewRateLimiter(map[string]RateLimit{
+		"bot/richmenu": {Requests: 1, Per: time.Hour, Exact: true},
+	})
+	fakeClock(l)
+
+	require.NoError(t, l.Wait(context.Background(), "bot/richmenu"))
+	for range 3 {
+		require.NoError(t, l.Wait(context.Background(), "bot/richmenu/list"))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.Wait(ctx, "bot/richmenu"), context.Canceled)
+}
+
+func TestRateLimiterRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/bot/profile/U1" && calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"The API rate limit has been exceeded. Try again later."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	limiter := NewRateLimiter(DefaultRateLimits())
+	slept := fakeClock(limiter)
+	httpClient := NewRetryableHTTPClient(WithRetryableHTTPClientRetryMax(0))
+	client, err := NewClient("test-token", WithBaseURL(ts.URL), WithClient(httpClient), WithRateLimiter(limiter))
+	require.NoError(t, err)
+
+	_, _, err = client.Bot.Profile(context.Background(), "U1")
+	assert.True(t, IsRateLimited(err))
+
+	var blocked []string
+	for _, s := range limiter.State() {
+		if !s.BlockedUntil.IsZero() {
+			blocked = append(blocked, s.Endpoint)
+		}
+	}
+	assert.Equal(t, []string{"bot/profile/{userId}"}, blocked)
+
+	// Other endpoints are not held back by the Retry-After of the profile.
+	opt := MessagePushOptions{To: "U1234567890", Messages: Messages{NewTextMessage("Hello, World!")}}
+	_, _, err = client.Message.Push(context.Background(), opt)
+	require.NoError(t, err)
+	assert.Zero(t, *slept)
+
+	_, _, err = client.Bot.Profile(context.Background(), "U1")
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, *slept)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,210 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrUnknownChannel is returned by Registry.Client for a channel that is
+// neither registered nor known to the ChannelLoader.
+var ErrUnknownChannel = errors.New("line: unknown channel")
+
+// ChannelLoader returns the client options of a channel that was not
+// registered up front, e.g. from a database. It returns ErrUnknownChannel if
+// the channel does not exist. Concurrent requests for a channel share a single
+// load, which runs with the context of the first request.
+type ChannelLoader func(ctx context.Context, channelID string) ([]ClientOptionFunc, error)
+
+type RegistryOptionFunc func(r *Registry)
+
+// WithRegistryHTTPClient sets the HTTP client shared by all channels.
+func WithRegistryHTTPClient(httpClient *http.Client) RegistryOptionFunc {
+	return func(r *Registry) {
+		r.httpClient = httpClient
+	}
+}
+
+// WithRegistryClientOptions sets options applied to every channel's client
+// before its own options. Per-channel state such as a RateLimiter belongs in
+// the channel options, since LINE limits each channel separately.
+func WithRegistryClientOptions(options ...ClientOptionFunc) RegistryOptionFunc {
+	return func(r *Registry) {
+		r.options = append(r.options, options...)
+	}
+}
+
+// WithChannelLoader sets the loader for channels that were not registered.
+func WithChannelLoader(loader ChannelLoader) RegistryOptionFunc {
+	return func(r *Registry) {
+		r.loader = loader
+	}
+}
+
+// Registry holds one Client per channel of a multi-tenant service. Clients
+// are created on first use and share a single HTTP client, so connections
+// and the retry transport are pooled across channels. It is safe for
+// concurrent use.
+type Registry struct {
+	httpClient *http.Client
+	options    []ClientOptionFunc
+	loader     ChannelLoader
+
+	// OAuth issues tokens for any channel, e.g. for NewStatelessTokenSource.
+	OAuth *OAuthService
+
+	mu       sync.Mutex
+	channels map[string][]ClientOptionFunc
+	clients  map[string]*Client
+	loads    map[string]*channelLoad
+}
+
+// channelLoad is a call of the ChannelLoader in flight. Register and
+// Unregister drop it from Registry.loads, which discards its result.
+type channelLoad struct {
+	done chan struct{}
+	err  error
+}
+
+func NewRegistry(options ...RegistryOptionFunc) (*Registry, error) {
+	r := &Registry{
+		channels: make(map[string][]ClientOptionFunc),
+		clients:  make(map[string]*Client),
+		loads:    make(map[string]*channelLoad),
+	}
+	for _, fn := range options {
+		if fn != nil {
+			fn(r)
+		}
+	}
+	if r.httpClient == nil {
+		r.httpClient = NewRetryableHTTPClient(WithLineRetryPolicy())
+	}
+
+	oauth, err := r.newClient()
+	if err != nil {
+		return nil, err
+	}
+	r.OAuth = oauth.OAuth
+
+	return r, nil
+}
+
+// Register adds a channel, typically with WithToken or WithTokenSource. It
+// replaces the client of a channel that was already registered.
+func (r *Registry) Register(channelID string, options ...ClientOptionFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.channels[channelID] = options
+	delete(r.clients, channelID)
+	delete(r.loads, channelID)
+}
+
+// Unregister removes a channel and its client. A load of the channel that is
+// in flight is discarded.
+func (r *Registry) Unregister(channelID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.channels, channelID)
+	delete(r.clients, channelID)
+	delete(r.loads, channelID)
+}
+
+// Client returns the client of the channel, creating it on first use. The
+// ChannelLoader runs without holding the registry lock, so a slow load does
+// not delay other channels.
+func (r *Registry) Client(ctx context.Context, channelID string) (*Client, error) {
+	r.mu.Lock()
+	c, ok, err := r.client(channelID)
+	if ok || r.loader == nil {
+		r.mu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownChannel, channelID)
+		}
+		return c, err
+	}
+	load, loading := r.loads[channelID]
+	if !loading {
+		load = &channelLoad{done: make(chan struct{})}
+		r.loads[channelID] = load
+	}
+	r.mu.Unlock()
+
+	if loading {
+		select {
+		case <-load.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else {
+		r.load(ctx, channelID, load)
+	}
+	if load.err != nil {
+		return nil, load.err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok, err = r.client(channelID)
+	if !ok {
+		// Unregistered while loading.
+		return nil, fmt.Errorf("%w: %s", ErrUnknownChannel, channelID)
+	}
+	return c, err
+}
+
+// load runs the ChannelLoader and keeps the options, unless the load was
+// discarded meanwhile.
+func (r *Registry) load(ctx context.Context, channelID string, load *channelLoad) {
+	defer close(load.done)
+	options, err := r.loader(ctx, channelID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	load.err = err
+	if r.loads[channelID] != load {
+		return
+	}
+	delete(r.loads, channelID)
+	if err == nil {
+		r.channels[channelID] = options
+	}
+}
+
+// client returns the client of a registered or loaded channel and whether the
+// channel is known. The caller must hold r.mu.
+func (r *Registry) client(channelID string) (*Client, bool, error) {
+	if c, ok := r.clients[channelID]; ok {
+		return c, true, nil
+	}
+
+	options, ok := r.channels[channelID]
+	if !ok {
+		return nil, false, nil
+	}
+	c, err := r.newClient(options...)
+	if err != nil {
+		return nil, true, fmt.Errorf("line: channel %s: %w", channelID, err)
+	}
+	r.clients[channelID] = c
+
+	return c, true, nil
+}
+
+func (r *Registry) newClient(options ...ClientOptionFunc) (*Client, error) {
+	// Unlike NewClient, the defaults come first so that WithToken in the
+	// channel options is not overridden.
+	all := []ClientOptionFunc{
+		WithUserAgent(userAgent),
+		WithApiVersionPath(apiVersionPath),
+	}
+	all = append(all, r.options...)
+	all = append(all, options...)
+	all = append(all, WithClient(r.httpClient))
+
+	return newClient(all...)
+}
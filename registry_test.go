@@ -0,0 +1,139 @@
+package line
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"userId":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer ts.Close()
+
+	httpClient := &http.Client{}
+	var loads atomic.Int32
+	registry, err := NewRegistry(
+		WithRegistryHTTPClient(httpClient),
+		WithRegistryClientOptions(WithBaseURL(ts.URL)),
+		WithChannelLoader(func(ctx context.Context, channelID string) ([]ClientOptionFunc, error) {
+			if channelID != "200" {
+				return nil, ErrUnknownChannel
+			}
+			loads.Add(1)
+			return []ClientOptionFunc{WithToken("token-200")}, nil
+		}),
+	)
+	require.NoError(t, err)
+	registry.Register("100", WithToken("token-100"))
+
+	var wg sync.WaitGroup
+	clients := make([]*Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := registry.Client(context.Background(), "200")
+			assert.NoError(t, err)
+			clients[i] = c
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), loads.Load())
+	for _, c := range clients {
+		assert.Same(t, clients[0], c)
+	}
+
+	for _, channelID := range []string{"100", "200"} {
+		c, err := registry.Client(context.Background(), channelID)
+		require.NoError(t, err)
+		assert.Same(t, httpClient, c.client)
+
+		profile, _, err := c.Bot.Profile(context.Background(), "U1")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-"+channelID, profile.UserID)
+	}
+
+	_, err = registry.Client(context.Background(), "300")
+	assert.ErrorIs(t, err, ErrUnknownChannel)
+
+	registry.Unregister("100")
+	registry.loader = nil
+	_, err = registry.Client(context.Background(), "100")
+	assert.ErrorIs(t, err, ErrUnknownChannel)
+}
+
+func TestRegistryLoaderUnlocked(t *testing.T) {
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	registry, err := NewRegistry(
+		WithChannelLoader(func(ctx context.Context, channelID string) ([]ClientOptionFunc, error) {
+			close(loading)
+			<-release
+			return []ClientOptionFunc{WithToken("token-200")}, nil
+		}),
+	)
+	require.NoError(t, err)
+	registry.Register("100", WithToken("token-100"))
+
+	loaded := make(chan *Client)
+	go func() {
+		c, err := registry.Client(context.Background(), "200")
+		assert.NoError(t, err)
+		loaded <- c
+	}()
+	<-loading
+
+	// The blocked loader must not hold up registered channels.
+	c, err := registry.Client(context.Background(), "100")
+	require.NoError(t, err)
+	assert.NotNil(t, c)
+
+	// A channel registered while it is being loaded takes precedence.
+	registry.Register("200", WithToken("token-registered"))
+	registered, err := registry.Client(context.Background(), "200")
+	require.NoError(t, err)
+
+	close(release)
+	assert.Same(t, registered, <-loaded)
+}
+
+func TestRegistryUnregisterWhileLoading(t *testing.T) {
+	var loads atomic.Int32
+	loading := make(chan struct{}, 1)
+	release := make(chan struct{})
+	registry, err := NewRegistry(
+		WithChannelLoader(func(ctx context.Context, channelID string) ([]ClientOptionFunc, error) {
+			loads.Add(1)
+			loading <- struct{}{}
+			<-release
+			return []ClientOptionFunc{WithToken("token-200")}, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	errs := make(chan error)
+	go func() {
+		_, err := registry.Client(context.Background(), "200")
+		errs <- err
+	}()
+	<-loading
+
+	registry.Unregister("200")
+	close(release)
+	assert.ErrorIs(t, <-errs, ErrUnknownChannel)
+	assert.NotContains(t, registry.channels, "200")
+
+	// The next request loads the channel again.
+	c, err := registry.Client(context.Background(), "200")
+	require.NoError(t, err)
+	assert.NotNil(t, c)
+	assert.Equal(t, int32(2), loads.Load())
+}
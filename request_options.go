@@ -2,9 +2,13 @@ package line
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
 	"net/http"
 )
 
+const retryKeyHeader = "X-Line-Retry-Key"
+
 type RequestOptionFunc func(*http.Request) error
 
 // WithContext runs the request with the provided context
@@ -37,3 +41,28 @@ func WithRequestHeaderFunc(fn func(http.Header)) RequestOptionFunc {
 		return nil
 	}
 }
+
+// WithRetryKey sets the X-Line-Retry-Key header. Requests sharing the same
+// retry key are accepted by LINE only once, so the key must stay the same
+// when a request is retried.
+func WithRetryKey(key string) RequestOptionFunc {
+	return func(req *http.Request) error {
+		req.Header.Set(retryKeyHeader, key)
+		return nil
+	}
+}
+
+// NewRetryKey returns a random UUID (version 4) to be used as a retry key.
+func NewRetryKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// withDefaultRetryKey prepends a fresh retry key so a key supplied by the
+// caller through WithRetryKey still takes precedence.
+func withDefaultRetryKey(options []RequestOptionFunc) []RequestOptionFunc {
+	return append([]RequestOptionFunc{WithRetryKey(NewRetryKey())}, options...)
+}
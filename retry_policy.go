@@ -0,0 +1,116 @@
+package line
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+type retryInfoKey struct{}
+
+// retryInfo describes the request being sent, since a CheckRetry only sees
+// the response, which is nil on transport errors.
+type retryInfo struct {
+	retryable bool
+}
+
+// withRetryInfo records on the request context whether the request is safe
+// to send twice, for LineCheckRetry.
+func withRetryInfo(req *http.Request) *http.Request {
+	info := retryInfo{retryable: isRetryableRequest(req)}
+	return req.WithContext(context.WithValue(req.Context(), retryInfoKey{}, info))
+}
+
+// isRetryableRequest reports whether sending req more than once has no
+// additional effect: it is idempotent or carries an X-Line-Retry-Key.
+func isRetryableRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(retryKeyHeader) != ""
+}
+
+// LineCheckRetry is a retryablehttp.CheckRetry that follows the semantics of
+// the LINE API:
+//
+//   - 4xx responses are never retried, except 429 caused by the rate limit.
+//     A 429 caused by the monthly message limit is not retried.
+//   - A 409 for an already accepted X-Line-Retry-Key is a success.
+//   - 5xx responses and transport errors are only retried for idempotent
+//     requests or requests carrying an X-Line-Retry-Key, because a POST may
+//     have been processed even though it failed.
+func LineCheckRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		if retry, _ := retryablehttp.DefaultRetryPolicy(ctx, resp, err); !retry {
+			return false, nil
+		}
+		info, _ := ctx.Value(retryInfoKey{}).(retryInfo)
+		return info.retryable, nil
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return !isQuotaResponse(resp), nil
+	case resp.StatusCode < 500, resp.StatusCode == http.StatusNotImplemented:
+		return false, nil
+	}
+
+	if resp.Request != nil {
+		return isRetryableRequest(resp.Request), nil
+	}
+	info, _ := ctx.Value(retryInfoKey{}).(retryInfo)
+	return info.retryable, nil
+}
+
+// isQuotaResponse peeks into the body of a 429 response to tell the monthly
+// limit apart from the rate limit. The body is restored for the caller.
+func isQuotaResponse(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return err == nil && isQuotaMessage(string(data))
+}
+
+// LineBackoff is a retryablehttp.Backoff waiting for Retry-After on 429 and
+// 503 responses, and for an exponentially growing, jittered duration between
+// minWait and maxWait otherwise.
+func LineBackoff(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d
+		}
+	}
+
+	backoff := maxWait
+	if attemptNum < 62 {
+		if shifted := minWait << attemptNum; shifted>>attemptNum == minWait && shifted > 0 {
+			backoff = min(shifted, maxWait)
+		}
+	}
+	if backoff <= minWait {
+		return minWait
+	}
+	return minWait + rand.N(backoff-minWait+1)
+}
+
+// WithLineRetryPolicy configures LineCheckRetry and LineBackoff. It is used
+// by the HTTP client NewClient creates when none is given with WithClient.
+func WithLineRetryPolicy() RetryableHTTPClientOption {
+	return func(client *retryablehttp.Client) {
+		client.CheckRetry = LineCheckRetry
+		client.Backoff = LineBackoff
+	}
+}
@@ -0,0 +1,106 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineCheckRetry(t *testing.T) {
+	post, _ := http.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/push", nil)
+	postWithKey, _ := http.NewRequest(http.MethodPost, "https://api.line.me/v2/bot/message/push", nil)
+	postWithKey.Header.Set("X-Line-Retry-Key", NewRetryKey())
+	get, _ := http.NewRequest(http.MethodGet, "https://api.line.me/v2/bot/profile/U123", nil)
+
+	response := func(req *http.Request, status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Request: req, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	tests := []struct {
+		name  string
+		resp  *http.Response
+		retry bool
+	}{
+		{"200", response(post, http.StatusOK, "{}"), false},
+		{"400", response(get, http.StatusBadRequest, "{}"), false},
+		{"401", response(get, http.StatusUnauthorized, "{}"), false},
+		{"403", response(postWithKey, http.StatusForbidden, "{}"), false},
+		{"409 accepted retry key", response(postWithKey, http.StatusConflict, "{}"), false},
+		{"429 rate limit", response(post, http.StatusTooManyRequests, `{"message":"The API rate limit has been exceeded. Try again later."}`), true},
+		{"429 monthly limit", response(post, http.StatusTooManyRequests, `{"message":"You have reached your monthly limit."}`), false},
+		{"500 post", response(post, http.StatusInternalServerError, "{}"), false},
+		{"500 post with retry key", response(postWithKey, http.StatusInternalServerError, "{}"), true},
+		{"502 get", response(get, http.StatusBadGateway, "{}"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry, err := LineCheckRetry(context.Background(), tt.resp, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.retry, retry)
+		})
+	}
+
+	// The body of a 429 stays readable after the check.
+	resp := response(post, http.StatusTooManyRequests, `{"message":"You have reached your monthly limit."}`)
+	_, _ = LineCheckRetry(context.Background(), resp, nil)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "monthly limit")
+
+	// Transport errors depend on the request recorded in the context.
+	connErr := errors.New("connection reset by peer")
+	retry, _ := LineCheckRetry(withRetryInfo(post).Context(), nil, connErr)
+	assert.False(t, retry)
+	retry, _ = LineCheckRetry(withRetryInfo(postWithKey).Context(), nil, connErr)
+	assert.True(t, retry)
+}
+
+func TestLineBackoff(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3"}}}
+	assert.Equal(t, 3*time.Second, LineBackoff(time.Second, 30*time.Second, 1, resp))
+
+	for attempt := range 10 {
+		d := LineBackoff(time.Second, 30*time.Second, attempt, nil)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, 30*time.Second)
+	}
+}
+
+func TestLineRetryPolicy(t *testing.T) {
+	calls := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	httpClient := NewRetryableHTTPClient(
+		WithLineRetryPolicy(),
+		WithRetryableHTTPClientRetryMax(2),
+		WithRetryableHTTPClientRetryWaitMin(time.Millisecond),
+		WithRetryableHTTPClientRetryWaitMax(time.Millisecond),
+	)
+	client, err := NewClient("test-token", WithBaseURL(ts.URL), WithClient(httpClient))
+	require.NoError(t, err)
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "bot/chat/loading/start", map[string]string{"chatId": "U123"}, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls["/v2/bot/chat/loading/start"])
+
+	_, err = client.Message.Multicast(context.Background(), MessageMulticastOptions{To: []string{"U1"}, Messages: Messages{NewTextMessage("hi")}})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls["/v2/bot/message/multicast"])
+
+	_, _, err = client.Bot.Profile(context.Background(), "U123")
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls["/v2/bot/profile/U123"])
+}
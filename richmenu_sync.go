@@ -0,0 +1,430 @@
+package line
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	maxRichMenuName = 300
+	// richMenuImageHashLength is the number of hex digits of the image's
+	// SHA-256 that Sync appends to the name of a menu.
+	richMenuImageHashLength = 16
+)
+
+// RichMenuSpec declares the rich menus of a channel, for RichMenuService.Sync.
+// Menus are identified by name, since LINE assigns the IDs and rich menus
+// cannot be updated in place.
+type RichMenuSpec struct {
+	Menus   []RichMenuSpecMenu  `json:"menus"`
+	Aliases []RichMenuSpecAlias `json:"aliases,omitempty"`
+	// Default is the name of the default rich menu; empty leaves the default
+	// unmanaged.
+	Default string `json:"default,omitempty"`
+}
+
+// RichMenuSpecMenu is a rich menu and the path of its image, relative to the
+// spec file. Sync creates the menu with a hash of the image appended to its
+// name, e.g. "main#3f2a9c1b7d4e5f60", so that a changed image replaces the
+// menu like any other change.
+type RichMenuSpecMenu struct {
+	RichMenu
+	Image string `json:"image"`
+}
+
+// RichMenuSpecAlias points the alias ID to the menu with the given name.
+type RichMenuSpecAlias struct {
+	ID   string `json:"id"`
+	Menu string `json:"menu"`
+}
+
+// LoadRichMenuSpec reads a JSON or YAML spec file. Image paths are resolved
+// relative to the directory of the file.
+func LoadRichMenuSpec(path string) (*RichMenuSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := ParseRichMenuSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	for i := range spec.Menus {
+		if image := spec.Menus[i].Image; image != "" && !filepath.IsAbs(image) {
+			spec.Menus[i].Image = filepath.Join(dir, image)
+		}
+	}
+	return spec, nil
+}
+
+// ParseRichMenuSpec parses a JSON or YAML spec. Both are read with the JSON
+// field names, so actions decode as in UnmarshalAction.
+func ParseRichMenuSpec(data []byte) (*RichMenuSpec, error) {
+	// JSON is valid YAML, so converting YAML to JSON handles both formats.
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := new(RichMenuSpec)
+	if err := json.Unmarshal(jsonData, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Validate checks that names are unique and references resolve.
+func (s *RichMenuSpec) Validate() error {
+	var v validator
+
+	names := make(map[string]bool, len(s.Menus))
+	for i, menu := range s.Menus {
+		p := indexPath("", "menus", i)
+		v.text(joinPath(p, "name"), menu.Name, maxRichMenuName-1-richMenuImageHashLength)
+		v.required(joinPath(p, "image"), menu.Image)
+		if names[menu.Name] {
+			v.addf(joinPath(p, "name"), "must be unique, got %q twice", menu.Name)
+		}
+		names[menu.Name] = true
+	}
+
+	aliases := make(map[string]bool, len(s.Aliases))
+	for i, alias := range s.Aliases {
+		p := indexPath("", "aliases", i)
+		v.required(joinPath(p, "id"), alias.ID)
+		if aliases[alias.ID] {
+			v.addf(joinPath(p, "id"), "must be unique, got %q twice", alias.ID)
+		}
+		aliases[alias.ID] = true
+		if !names[alias.Menu] {
+			v.addf(joinPath(p, "menu"), "must name a menu of the spec, got %q", alias.Menu)
+		}
+	}
+
+	if s.Default != "" && !names[s.Default] {
+		v.addf("default", "must name a menu of the spec, got %q", s.Default)
+	}
+
+	return v.err()
+}
+
+type RichMenuSyncOpType string
+
+const (
+	RichMenuSyncCreate      RichMenuSyncOpType = "create"
+	RichMenuSyncDelete      RichMenuSyncOpType = "delete"
+	RichMenuSyncCreateAlias RichMenuSyncOpType = "create-alias"
+	RichMenuSyncUpdateAlias RichMenuSyncOpType = "update-alias"
+	RichMenuSyncDeleteAlias RichMenuSyncOpType = "delete-alias"
+	RichMenuSyncSetDefault  RichMenuSyncOpType = "set-default"
+)
+
+// RichMenuSyncOp is a single step of a RichMenuSyncPlan. Menu is the name of
+// the menu; RichMenuID is empty for menus that are yet to be created.
+type RichMenuSyncOp struct {
+	Type       RichMenuSyncOpType
+	Menu       string
+	RichMenuID string
+	AliasID    string
+}
+
+func (op RichMenuSyncOp) String() string {
+	var b strings.Builder
+	b.WriteString(string(op.Type))
+	if op.AliasID != "" {
+		fmt.Fprintf(&b, " alias %q", op.AliasID)
+	}
+	if op.Menu != "" {
+		fmt.Fprintf(&b, " menu %q", op.Menu)
+	}
+	if op.RichMenuID != "" {
+		fmt.Fprintf(&b, " (%s)", op.RichMenuID)
+	}
+	return b.String()
+}
+
+// RichMenuSyncPlan lists the operations that bring the channel in line with
+// a spec, in the order they are applied: menus are created before aliases
+// and the default point to them, and deleted last.
+type RichMenuSyncPlan struct {
+	Ops []RichMenuSyncOp
+}
+
+// Empty reports whether the channel already matches the spec.
+func (p *RichMenuSyncPlan) Empty() bool {
+	return len(p.Ops) == 0
+}
+
+func (p *RichMenuSyncPlan) String() string {
+	if p.Empty() {
+		return "no changes\n"
+	}
+	var b strings.Builder
+	for _, op := range p.Ops {
+		b.WriteString(op.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+type RichMenuSyncOptions struct {
+	// DryRun only plans the operations, without applying them.
+	DryRun bool
+	// Prune deletes rich menus and aliases that are not in the spec. Menus
+	// replaced by a changed spec menu of the same name are always deleted.
+	Prune bool
+	// Output receives the plan, one operation per line, as it is applied.
+	Output io.Writer
+}
+
+// Sync makes the rich menus of the channel match spec, applying only the
+// operations needed, and returns the plan. It stops at the first failed
+// operation; running it again resumes from the state reached.
+func (s *RichMenuService) Sync(ctx context.Context, spec *RichMenuSpec, opt RichMenuSyncOptions, options ...RequestOptionFunc) (*RichMenuSyncPlan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	for _, menu := range spec.Menus {
+		if _, err := richMenuImageType(menu.Image); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(menu.Image); err != nil {
+			return nil, err
+		}
+	}
+
+	plan, ids, err := s.plan(ctx, spec, opt.Prune, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Output != nil && plan.Empty() {
+		_, _ = io.WriteString(opt.Output, plan.String())
+	}
+	for _, op := range plan.Ops {
+		if opt.Output != nil {
+			_, _ = fmt.Fprintln(opt.Output, op)
+		}
+		if opt.DryRun {
+			continue
+		}
+		if err := s.apply(ctx, spec, op, ids, options); err != nil {
+			return plan, fmt.Errorf("line: rich menu sync: %s: %w", op, err)
+		}
+	}
+
+	return plan, nil
+}
+
+// plan diffs spec against the channel. ids maps the names of the spec menus
+// that already exist to their IDs.
+func (s *RichMenuService) plan(ctx context.Context, spec *RichMenuSpec, prune bool, options []RequestOptionFunc) (*RichMenuSyncPlan, map[string]string, error) {
+	existing, _, err := s.List(ctx, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	aliases, _, err := s.ListAliases(ctx, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defaultID := ""
+	if def, _, err := s.GetDefault(ctx, options...); err == nil {
+		defaultID = def.RichMenuID
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, nil, err
+	}
+
+	plan := new(RichMenuSyncPlan)
+	ids := make(map[string]string, len(spec.Menus))
+	kept := make(map[string]bool, len(existing))
+
+	wanted := make(map[string]bool, len(spec.Menus))
+	for _, menu := range spec.Menus {
+		wanted[menu.Name] = true
+		synced, err := syncedRichMenu(menu)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range existing {
+			if !kept[e.RichMenuID] && equalRichMenus(synced, e) {
+				ids[menu.Name] = e.RichMenuID
+				kept[e.RichMenuID] = true
+				break
+			}
+		}
+		if _, ok := ids[menu.Name]; !ok {
+			plan.Ops = append(plan.Ops, RichMenuSyncOp{Type: RichMenuSyncCreate, Menu: menu.Name})
+		}
+	}
+
+	aliasTargets := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		aliasTargets[alias.RichMenuAliasID] = alias.RichMenuID
+	}
+	for _, alias := range spec.Aliases {
+		target, ok := aliasTargets[alias.ID]
+		switch {
+		case !ok:
+			plan.Ops = append(plan.Ops, RichMenuSyncOp{Type: RichMenuSyncCreateAlias, AliasID: alias.ID, Menu: alias.Menu, RichMenuID: ids[alias.Menu]})
+		case target != ids[alias.Menu]:
+			plan.Ops = append(plan.Ops, RichMenuSyncOp{Type: RichMenuSyncUpdateAlias, AliasID: alias.ID, Menu: alias.Menu, RichMenuID: ids[alias.Menu]})
+		}
+		delete(aliasTargets, alias.ID)
+	}
+
+	if spec.Default != "" && (defaultID == "" || defaultID != ids[spec.Default]) {
+		plan.Ops = append(plan.Ops, RichMenuSyncOp{Type: RichMenuSyncSetDefault, Menu: spec.Default, RichMenuID: ids[spec.Default]})
+	}
+
+	// Deletions come last, once nothing points to the old menus anymore.
+	deletedMenus := make(map[string]bool)
+	for _, e := range existing {
+		if kept[e.RichMenuID] || !prune && !wanted[specRichMenuName(e.Name)] {
+			continue
+		}
+		deletedMenus[e.RichMenuID] = true
+	}
+	for _, alias := range aliases {
+		target, ok := aliasTargets[alias.RichMenuAliasID]
+		if ok && (prune || deletedMenus[target]) {
+			plan.Ops = append(plan.Ops, RichMenuSyncOp{Type: RichMenuSyncDeleteAlias, AliasID: alias.RichMenuAliasID, RichMenuID: target})
+		}
+	}
+	for _, e := range existing {
+		if deletedMenus[e.RichMenuID] {
+			plan.Ops = append(plan.Ops, RichMenuSyncOp{Type: RichMenuSyncDelete, Menu: specRichMenuName(e.Name), RichMenuID: e.RichMenuID})
+		}
+	}
+
+	return plan, ids, nil
+}
+
+func (s *RichMenuService) apply(ctx context.Context, spec *RichMenuSpec, op RichMenuSyncOp, ids map[string]string, options []RequestOptionFunc) error {
+	richMenuID := op.RichMenuID
+	if richMenuID == "" {
+		richMenuID = ids[op.Menu]
+	}
+
+	var err error
+	switch op.Type {
+	case RichMenuSyncCreate:
+		for _, menu := range spec.Menus {
+			if menu.Name == op.Menu {
+				ids[op.Menu], err = s.createWithImage(ctx, menu, options)
+				break
+			}
+		}
+	case RichMenuSyncDelete:
+		_, err = s.Delete(ctx, richMenuID, options...)
+	case RichMenuSyncCreateAlias:
+		_, err = s.CreateAlias(ctx, RichMenuAlias{RichMenuAliasID: op.AliasID, RichMenuID: richMenuID}, options...)
+	case RichMenuSyncUpdateAlias:
+		_, err = s.UpdateAlias(ctx, op.AliasID, RichMenuAliasUpdateOptions{RichMenuID: richMenuID}, options...)
+	case RichMenuSyncDeleteAlias:
+		_, err = s.DeleteAlias(ctx, op.AliasID, options...)
+	case RichMenuSyncSetDefault:
+		_, err = s.SetDefault(ctx, richMenuID, options...)
+	default:
+		err = fmt.Errorf("unknown operation %q", op.Type)
+	}
+	return err
+}
+
+// createWithImage creates the menu and uploads its image, deleting the menu
+// again if the upload fails so that no menu without an image is left behind.
+func (s *RichMenuService) createWithImage(ctx context.Context, menu RichMenuSpecMenu, options []RequestOptionFunc) (string, error) {
+	contentType, err := richMenuImageType(menu.Image)
+	if err != nil {
+		return "", err
+	}
+	image, err := os.ReadFile(menu.Image)
+	if err != nil {
+		return "", err
+	}
+
+	synced := menu.RichMenu
+	synced.Name = syncedRichMenuName(menu.Name, image)
+	created, _, err := s.Create(ctx, &synced, options...)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.UploadImage(ctx, created.RichMenuID, contentType, bytes.NewReader(image), options...); err != nil {
+		_, _ = s.Delete(ctx, created.RichMenuID, options...)
+		return "", err
+	}
+	return created.RichMenuID, nil
+}
+
+func richMenuImageType(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		return "image/png", nil
+	case ".jpg", ".jpeg":
+		return "image/jpeg", nil
+	}
+	return "", fmt.Errorf("line: rich menu image %s must be a PNG or JPEG file", path)
+}
+
+// syncedRichMenu returns the menu as Sync creates it, with the hash of its
+// image in the name.
+func syncedRichMenu(menu RichMenuSpecMenu) (*RichMenu, error) {
+	image, err := os.ReadFile(menu.Image)
+	if err != nil {
+		return nil, err
+	}
+	synced := menu.RichMenu
+	synced.Name = syncedRichMenuName(menu.Name, image)
+	return &synced, nil
+}
+
+func syncedRichMenuName(name string, image []byte) string {
+	sum := sha256.Sum256(image)
+	return name + "#" + hex.EncodeToString(sum[:])[:richMenuImageHashLength]
+}
+
+// specRichMenuName strips the image hash from the name of a synced menu.
+func specRichMenuName(name string) string {
+	i := strings.LastIndexByte(name, '#')
+	if i < 0 || len(name)-i-1 != richMenuImageHashLength {
+		return name
+	}
+	if _, err := hex.DecodeString(name[i+1:]); err != nil {
+		return name
+	}
+	return name[:i]
+}
+
+// equalRichMenus compares the menus without their IDs.
+func equalRichMenus(a, b *RichMenu) bool {
+	x, y := *a, *b
+	x.RichMenuID, y.RichMenuID = "", ""
+
+	xData, err := json.Marshal(x)
+	if err != nil {
+		return false
+	}
+	yData, err := json.Marshal(y)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(xData, yData)
+}
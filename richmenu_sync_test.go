@@ -0,0 +1,237 @@
+package line
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const richMenuSpecYAML = `
+menus:
+  - name: main
+    size: {width: 2500, height: 843}
+    selected: true
+    chatBarText: Menu
+    image: main.png
+    areas:
+      - bounds: {x: 0, y: 0, width: 2500, height: 843}
+        action: {type: richmenuswitch, richMenuAliasId: page-2, data: page=2}
+  - name: page-2
+    size: {width: 2500, height: 843}
+    chatBarText: More
+    image: page-2.jpg
+    areas:
+      - bounds: {x: 0, y: 0, width: 2500, height: 843}
+        action: {type: message, text: hello}
+aliases:
+  - {id: page-1, menu: main}
+  - {id: page-2, menu: page-2}
+default: main
+`
+
+// fakeRichMenuAPI keeps the rich menus of a channel in memory.
+type fakeRichMenuAPI struct {
+	mu        sync.Mutex
+	next      int
+	menus     map[string]*RichMenu
+	images    map[string]string
+	aliases   map[string]string
+	defaultID string
+	calls     []string
+}
+
+func newFakeRichMenuAPI(t *testing.T) (*fakeRichMenuAPI, *httptest.Server) {
+	api := &fakeRichMenuAPI{
+		menus:   make(map[string]*RichMenu),
+		images:  make(map[string]string),
+		aliases: make(map[string]string),
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, "/v2/")
+		if r.Method != http.MethodGet {
+			api.calls = append(api.calls, r.Method+" "+path)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && path == "bot/richmenu/list":
+			menus := make([]*RichMenu, 0, len(api.menus))
+			for i := 1; i <= api.next; i++ {
+				if m, ok := api.menus[fmt.Sprintf("richmenu-%d", i)]; ok {
+					menus = append(menus, m)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(RichMenuListResponse{RichMenus: menus})
+		case r.Method == http.MethodGet && path == "bot/richmenu/alias/list":
+			aliases := []*RichMenuAlias{}
+			for id, target := range api.aliases {
+				aliases = append(aliases, &RichMenuAlias{RichMenuAliasID: id, RichMenuID: target})
+			}
+			_ = json.NewEncoder(w).Encode(RichMenuAliasListResponse{Aliases: aliases})
+		case r.Method == http.MethodGet && path == "bot/user/all/richmenu":
+			if api.defaultID == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"no default rich menu"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(RichMenuIDResponse{RichMenuID: api.defaultID})
+		case r.Method == http.MethodPost && path == "bot/richmenu":
+			menu := new(RichMenu)
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(menu)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			api.next++
+			menu.RichMenuID = fmt.Sprintf("richmenu-%d", api.next)
+			api.menus[menu.RichMenuID] = menu
+			_ = json.NewEncoder(w).Encode(RichMenuIDResponse{RichMenuID: menu.RichMenuID})
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/content"):
+			api.images[strings.TrimSuffix(strings.TrimPrefix(path, "bot/richmenu/"), "/content")] = r.Header.Get("Content-Type")
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "bot/richmenu/alias/"):
+			delete(api.aliases, strings.TrimPrefix(path, "bot/richmenu/alias/"))
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "bot/richmenu/"):
+			delete(api.menus, strings.TrimPrefix(path, "bot/richmenu/"))
+		case r.Method == http.MethodPost && path == "bot/richmenu/alias":
+			var alias RichMenuAlias
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&alias)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			api.aliases[alias.RichMenuAliasID] = alias.RichMenuID
+		case r.Method == http.MethodPost && strings.HasPrefix(path, "bot/richmenu/alias/"):
+			var opt RichMenuAliasUpdateOptions
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&opt)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			api.aliases[strings.TrimPrefix(path, "bot/richmenu/alias/")] = opt.RichMenuID
+		case r.Method == http.MethodPost && strings.HasPrefix(path, "bot/user/all/richmenu/"):
+			api.defaultID = strings.TrimPrefix(path, "bot/user/all/richmenu/")
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, path)
+		}
+	}))
+	return api, ts
+}
+
+func writeRichMenuSpec(t *testing.T, spec string) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.png"), []byte("png"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "page-2.jpg"), []byte("jpg"), 0o600))
+	path := filepath.Join(dir, "richmenu.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(spec), 0o600))
+	return path
+}
+
+func TestRichMenuSync(t *testing.T) {
+	api, ts := newFakeRichMenuAPI(t)
+	defer ts.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(ts.URL), WithDataBaseURL(ts.URL))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	spec, err := LoadRichMenuSpec(writeRichMenuSpec(t, richMenuSpecYAML))
+	require.NoError(t, err)
+	assert.Equal(t, NewRichMenuSwitchAction("", "page-2", "page=2"), spec.Menus[0].Areas[0].Action)
+
+	// Dry run prints the plan without changing anything.
+	var out bytes.Buffer
+	plan, err := client.RichMenu.Sync(ctx, spec, RichMenuSyncOptions{DryRun: true, Output: &out})
+	require.NoError(t, err)
+	assert.Equal(t, `create menu "main"
+create menu "page-2"
+create-alias alias "page-1" menu "main"
+create-alias alias "page-2" menu "page-2"
+set-default menu "main"
+`, out.String())
+	assert.Equal(t, out.String(), plan.String())
+	assert.Empty(t, api.calls)
+
+	_, err = client.RichMenu.Sync(ctx, spec, RichMenuSyncOptions{})
+	require.NoError(t, err)
+	assert.Len(t, api.menus, 2)
+	assert.Equal(t, map[string]string{"richmenu-1": "image/png", "richmenu-2": "image/jpeg"}, api.images)
+	assert.Equal(t, map[string]string{"page-1": "richmenu-1", "page-2": "richmenu-2"}, api.aliases)
+	assert.Equal(t, "richmenu-1", api.defaultID)
+
+	// A second run has nothing to do.
+	api.calls = nil
+	plan, err = client.RichMenu.Sync(ctx, spec, RichMenuSyncOptions{})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+	assert.Empty(t, api.calls)
+
+	// A changed menu is replaced, and what pointed to it follows.
+	spec.Menus[0].ChatBarText = "Open"
+	_, err = client.RichMenu.Sync(ctx, spec, RichMenuSyncOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST bot/richmenu",
+		"POST bot/richmenu/richmenu-3/content",
+		"POST bot/richmenu/alias/page-1",
+		"POST bot/user/all/richmenu/richmenu-3",
+		"DELETE bot/richmenu/richmenu-1",
+	}, api.calls)
+	assert.Equal(t, "richmenu-3", api.aliases["page-1"])
+
+	// So is a menu whose image changed on disk.
+	require.NoError(t, os.WriteFile(spec.Menus[0].Image, []byte("new png"), 0o600))
+	plan, err = client.RichMenu.Sync(ctx, spec, RichMenuSyncOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `create menu "main"
+update-alias alias "page-1" menu "main"
+set-default menu "main"
+delete menu "main" (richmenu-3)
+`, plan.String())
+	assert.NotContains(t, api.menus, "richmenu-3")
+	assert.Equal(t, "richmenu-4", api.defaultID)
+	assert.Contains(t, api.menus, "richmenu-2")
+	assert.True(t, strings.HasPrefix(api.menus["richmenu-4"].Name, "main#"))
+
+	// Menus and aliases outside the spec are only removed when pruning.
+	api.menus["richmenu-9"] = &RichMenu{RichMenuID: "richmenu-9", Name: "other"}
+	api.next = 9
+	api.aliases["other"] = "richmenu-9"
+	plan, err = client.RichMenu.Sync(ctx, spec, RichMenuSyncOptions{})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+
+	plan, err = client.RichMenu.Sync(ctx, spec, RichMenuSyncOptions{Prune: true})
+	require.NoError(t, err)
+	assert.Equal(t, []RichMenuSyncOp{
+		{Type: RichMenuSyncDeleteAlias, AliasID: "other", RichMenuID: "richmenu-9"},
+		{Type: RichMenuSyncDelete, Menu: "other", RichMenuID: "richmenu-9"},
+	}, plan.Ops)
+	assert.NotContains(t, api.menus, "richmenu-9")
+}
+
+func TestRichMenuSpecValidate(t *testing.T) {
+	spec, err := ParseRichMenuSpec([]byte(`{
+		"menus": [{"name": "main", "image": "a.png"}, {"name": "main"}],
+		"aliases": [{"id": "page-1", "menu": "missing"}],
+		"default": "missing"
+	}`))
+	require.NoError(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, spec.Validate(), &verr)
+	var properties []string
+	for _, d := range verr.Details {
+		properties = append(properties, d.Property)
+	}
+	assert.Equal(t, []string{"menus[1].image", "menus[1].name", "aliases[0].menu", "default"}, properties)
+}
@@ -0,0 +1,81 @@
+package line
+
+import "encoding/json"
+
+// RichMenu https://developers.line.biz/en/reference/messaging-api/#rich-menu-object
+type RichMenu struct {
+	RichMenuID  string         `json:"richMenuId,omitempty"` // Set by LINE, ignored on create
+	Size        RichMenuSize   `json:"size"`
+	Selected    bool           `json:"selected"`
+	Name        string         `json:"name"`
+	ChatBarText string         `json:"chatBarText"`
+	Areas       []RichMenuArea `json:"areas"`
+}
+
+// RichMenuSize is the size of the rich menu image in pixels: 800 to 2500
+// wide, and at least 250 high with an aspect ratio of at least 1.45.
+type RichMenuSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// RichMenuArea is a tappable area of the rich menu image.
+type RichMenuArea struct {
+	Bounds RichMenuBounds `json:"bounds"`
+	Action Action         `json:"action"`
+}
+
+// RichMenuBounds is the position and size of an area in pixels, relative to
+// the top left of the image.
+type RichMenuBounds struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func (a *RichMenuArea) UnmarshalJSON(data []byte) error {
+	type alias RichMenuArea
+	raw := struct {
+		*alias
+		Action json.RawMessage `json:"action"`
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	a.Action, err = unmarshalOptionalAction(raw.Action)
+	return err
+}
+
+// RichMenuAlias https://developers.line.biz/en/reference/messaging-api/#rich-menu-alias
+type RichMenuAlias struct {
+	RichMenuAliasID string `json:"richMenuAliasId"`
+	RichMenuID      string `json:"richMenuId"`
+}
+
+type RichMenuIDResponse struct {
+	RichMenuID string `json:"richMenuId"`
+}
+
+type RichMenuListResponse struct {
+	RichMenus []*RichMenu `json:"richmenus"`
+}
+
+type RichMenuAliasListResponse struct {
+	Aliases []*RichMenuAlias `json:"aliases"`
+}
+
+type RichMenuBulkLinkOptions struct {
+	RichMenuID string   `json:"richMenuId"`
+	UserIDs    []string `json:"userIds"`
+}
+
+type RichMenuBulkUnlinkOptions struct {
+	UserIDs []string `json:"userIds"`
+}
+
+type RichMenuAliasUpdateOptions struct {
+	RichMenuID string `json:"richMenuId"`
+}
@@ -0,0 +1,155 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed, so
+// that it does not expire while a request is in flight.
+const tokenExpiryDelta = time.Minute
+
+// Token is a bearer token for the Authorization header.
+type Token struct {
+	AccessToken string
+	// Expiry is the zero time for tokens that do not expire.
+	Expiry time.Time
+}
+
+// Valid reports whether the token is set and does not expire within
+// tokenExpiryDelta of now.
+func (t *Token) Valid(now time.Time) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || now.Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenSource supplies the token for each request. Implementations must be
+// safe for concurrent use by multiple goroutines.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+// StaticTokenSource returns a TokenSource that always returns the same
+// token, such as a long-lived channel access token.
+func StaticTokenSource(accessToken string) TokenSource {
+	return staticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+func (s staticTokenSource) Token(context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+// reuseTokenSource caches the token of src until shortly before it expires.
+type reuseTokenSource struct {
+	src TokenSource
+	now func() time.Time
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// ReuseTokenSource returns a TokenSource that returns t while it is valid
+// and asks src for a new token otherwise. Concurrent callers wait for a
+// single refresh instead of each issuing a token. t may be nil.
+func ReuseTokenSource(t *Token, src TokenSource) TokenSource {
+	if rs, ok := src.(*reuseTokenSource); ok {
+		if t == nil {
+			return rs
+		}
+		src = rs.src
+	}
+	return &reuseTokenSource{src: src, now: time.Now, token: t}
+}
+
+func (s *reuseTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid(s.now()) {
+		return s.token, nil
+	}
+
+	t, err := s.src.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = t
+	return t, nil
+}
+
+type issueFunc func(ctx context.Context) (*ChannelAccessToken, error)
+
+// channelTokenSource issues a new channel access token on every call.
+type channelTokenSource struct {
+	issue issueFunc
+	now   func() time.Time
+}
+
+func (s *channelTokenSource) Token(ctx context.Context) (*Token, error) {
+	now := s.now()
+	t, err := s.issue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t.AccessToken == "" {
+		return nil, errors.New("line: issued channel access token is empty")
+	}
+
+	token := &Token{AccessToken: t.AccessToken}
+	if t.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+func newChannelTokenSource(issue issueFunc) TokenSource {
+	return ReuseTokenSource(nil, &channelTokenSource{issue: issue, now: time.Now})
+}
+
+// NewShortLivedTokenSource returns a TokenSource that issues short-lived
+// channel access tokens with the channel ID and secret, and reissues them
+// shortly before they expire.
+func NewShortLivedTokenSource(oauth *OAuthService, channelID, channelSecret string) TokenSource {
+	return newChannelTokenSource(func(ctx context.Context) (*ChannelAccessToken, error) {
+		t, _, err := oauth.IssueShortLivedToken(ctx, IssueShortLivedTokenOptions{
+			ClientID:     channelID,
+			ClientSecret: channelSecret,
+		})
+		return t, err
+	})
+}
+
+// NewAssertionTokenSource returns a TokenSource that issues channel access
+// tokens v2.1 with a freshly signed assertion, and reissues them shortly
+// before they expire.
+func NewAssertionTokenSource(oauth *OAuthService, assertion *ClientAssertion) TokenSource {
+	return newChannelTokenSource(func(ctx context.Context) (*ChannelAccessToken, error) {
+		jwt, err := assertion.Sign(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		t, _, err := oauth.IssueToken(ctx, IssueTokenOptions{ClientAssertion: jwt})
+		return t, err
+	})
+}
+
+// NewStatelessTokenSource returns a TokenSource that issues stateless
+// channel access tokens with the channel ID and secret, and reissues them
+// shortly before they expire.
+func NewStatelessTokenSource(oauth *OAuthService, channelID, channelSecret string) TokenSource {
+	return newChannelTokenSource(func(ctx context.Context) (*ChannelAccessToken, error) {
+		t, _, err := oauth.IssueStatelessToken(ctx, IssueStatelessTokenOptions{
+			ClientID:     channelID,
+			ClientSecret: channelSecret,
+		})
+		return t, err
+	})
+}
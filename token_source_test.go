@@ -0,0 +1,87 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTokenSource struct {
+	calls  atomic.Int32
+	expiry time.Time
+	err    error
+}
+
+func (s *countingTokenSource) Token(context.Context) (*Token, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.calls.Add(1)
+	time.Sleep(10 * time.Millisecond)
+	return &Token{AccessToken: "issued", Expiry: s.expiry}, nil
+}
+
+func TestReuseTokenSource(t *testing.T) {
+	src := &countingTokenSource{expiry: time.Now().Add(time.Hour)}
+	reuse := ReuseTokenSource(nil, src)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := reuse.Token(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "issued", token.AccessToken)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), src.calls.Load())
+
+	// A token close to expiry is replaced.
+	expiring := &Token{AccessToken: "old", Expiry: time.Now().Add(30 * time.Second)}
+	token, err := ReuseTokenSource(expiring, src).Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "issued", token.AccessToken)
+
+	// Wrapping twice does not add a second cache.
+	assert.Same(t, reuse, ReuseTokenSource(nil, reuse))
+}
+
+func TestClientTokenSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"Bearer issued"}, r.Header.Values("Authorization"))
+		_, _ = w.Write([]byte(`{"userId":"U1"}`))
+	}))
+	defer ts.Close()
+
+	src := &countingTokenSource{expiry: time.Now().Add(time.Hour)}
+	client, err := NewClient("ignored", WithBaseURL(ts.URL), WithTokenSource(ReuseTokenSource(nil, src)))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Bot.Profile(context.Background(), "U1")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), src.calls.Load())
+
+	errToken := errors.New("no token")
+	client, err = NewClient("", WithBaseURL(ts.URL), WithTokenSource(&countingTokenSource{err: errToken}))
+	require.NoError(t, err)
+	_, _, err = client.Bot.Profile(context.Background(), "U1")
+	assert.ErrorIs(t, err, errToken)
+}
@@ -1,5 +1,7 @@
 package line
 
+import "time"
+
 type UserProfile struct {
 	DisplayName   string `json:"displayName,omitempty"`
 	UserID        string `json:"userId,omitempty"`
@@ -17,6 +19,61 @@ type SentMessage struct {
 	QuoteToken string `json:"quoteToken"`
 }
 
+type NarrowcastResponse struct {
+	RequestID string `json:"requestId"`
+}
+
+type NarrowcastProgressResponse struct {
+	Phase             string     `json:"phase"` // waiting, sending, succeeded or failed
+	SuccessCount      int64      `json:"successCount,omitempty"`
+	FailureCount      int64      `json:"failureCount,omitempty"`
+	TargetCount       int64      `json:"targetCount,omitempty"`
+	FailedDescription string     `json:"failedDescription,omitempty"`
+	ErrorCode         int        `json:"errorCode,omitempty"`
+	AcceptedTime      *time.Time `json:"acceptedTime,omitempty"`
+	CompletedTime     *time.Time `json:"completedTime,omitempty"`
+}
+
+type MessageQuota struct {
+	Type  string `json:"type"` // none or limited
+	Value int64  `json:"value,omitempty"`
+}
+
+type MessageQuotaConsumption struct {
+	TotalUsage int64 `json:"totalUsage"`
+}
+
+type MessageDeliveryCount struct {
+	Status  string `json:"status"` // ready, unready or out_of_service
+	Success int64  `json:"success,omitempty"`
+}
+
+type MessageContent struct {
+	ContentType   string `json:"contentType"`
+	ContentLength int64  `json:"contentLength"`
+}
+
+type MessageContentTranscoding struct {
+	Status string `json:"status"` // processing, succeeded or failed
+}
+
+type ChannelAccessToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"` // Seconds
+	TokenType   string `json:"token_type"`
+	KeyID       string `json:"key_id,omitempty"`
+}
+
+type VerifiedAccessToken struct {
+	ClientID  string `json:"client_id"`
+	ExpiresIn int64  `json:"expires_in"` // Seconds
+	Scope     string `json:"scope"`
+}
+
+type ChannelAccessTokenKeyIDs struct {
+	Kids []string `json:"kids"`
+}
+
 type ValidatePushResponse struct {
 	Message string        `json:"message"` // 主错误消息
 	Details []ErrorDetail `json:"details"` // 错误详情
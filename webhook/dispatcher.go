@@ -12,9 +12,27 @@ import (
 
 // Dispatcher is a dispatcher for webhook events.
 type Dispatcher struct {
-	secret            string
-	followListeners   []FollowListener
-	unFollowListeners []UnFollowListener
+	secret                     string
+	logger                     Logger
+	followListeners            []FollowListener
+	unFollowListeners          []UnFollowListener
+	messageListeners           []MessageListener
+	postbackListeners          []PostbackListener
+	joinListeners              []JoinListener
+	leaveListeners             []LeaveListener
+	memberJoinedListeners      []MemberJoinedListener
+	memberLeftListeners        []MemberLeftListener
+	unsendListeners            []UnsendListener
+	beaconListeners            []BeaconListener
+	accountLinkListeners       []AccountLinkListener
+	videoPlayCompleteListeners []VideoPlayCompleteListener
+	thingsListeners            []ThingsListener
+	activatedListeners         []ActivatedListener
+	deactivatedListeners       []DeactivatedListener
+	botSuspendedListeners      []BotSuspendedListener
+	botResumedListeners        []BotResumedListener
+	membershipListeners        []MembershipListener
+	fallbackListeners          []FallbackListener
 }
 
 func (d *Dispatcher) Registers(listeners ...any) {
@@ -25,6 +43,57 @@ func (d *Dispatcher) Registers(listeners ...any) {
 		if l, ok := listener.(UnFollowListener); ok {
 			d.UnFollowListener(l)
 		}
+		if l, ok := listener.(MessageListener); ok {
+			d.MessageListener(l)
+		}
+		if l, ok := listener.(PostbackListener); ok {
+			d.PostbackListener(l)
+		}
+		if l, ok := listener.(JoinListener); ok {
+			d.JoinListener(l)
+		}
+		if l, ok := listener.(LeaveListener); ok {
+			d.LeaveListener(l)
+		}
+		if l, ok := listener.(MemberJoinedListener); ok {
+			d.MemberJoinedListener(l)
+		}
+		if l, ok := listener.(MemberLeftListener); ok {
+			d.MemberLeftListener(l)
+		}
+		if l, ok := listener.(UnsendListener); ok {
+			d.UnsendListener(l)
+		}
+		if l, ok := listener.(BeaconListener); ok {
+			d.BeaconListener(l)
+		}
+		if l, ok := listener.(AccountLinkListener); ok {
+			d.AccountLinkListener(l)
+		}
+		if l, ok := listener.(VideoPlayCompleteListener); ok {
+			d.VideoPlayCompleteListener(l)
+		}
+		if l, ok := listener.(ThingsListener); ok {
+			d.ThingsListener(l)
+		}
+		if l, ok := listener.(ActivatedListener); ok {
+			d.ActivatedListener(l)
+		}
+		if l, ok := listener.(DeactivatedListener); ok {
+			d.DeactivatedListener(l)
+		}
+		if l, ok := listener.(BotSuspendedListener); ok {
+			d.BotSuspendedListener(l)
+		}
+		if l, ok := listener.(BotResumedListener); ok {
+			d.BotResumedListener(l)
+		}
+		if l, ok := listener.(MembershipListener); ok {
+			d.MembershipListener(l)
+		}
+		if l, ok := listener.(FallbackListener); ok {
+			d.FallbackListener(l)
+		}
 	}
 }
 
@@ -42,6 +111,14 @@ func WithSecret(secret string) Option {
 	}
 }
 
+// WithLogger replaces log.Default() as the logger of the dispatcher. A nil
+// logger discards everything.
+func WithLogger(logger Logger) Option {
+	return func(d *Dispatcher) {
+		d.logger = orDiscard(logger)
+	}
+}
+
 type dispatchRequestOptions struct {
 	ctx context.Context
 }
@@ -56,20 +133,28 @@ func (d *Dispatcher) DispatchRequest(req *http.Request, opts ...DispatchRequestO
 		opt(o)
 	}
 
+	body, err := readRequestBody(req, d.getLogger())
+	if err != nil {
+		return err
+	}
+
+	signature := req.Header.Get("x-line-signature")
+	return d.DispatchBody(o.ctx, signature, body)
+}
+
+func readRequestBody(req *http.Request, logger Logger) ([]byte, error) {
 	defer func(body io.ReadCloser) {
 		err := body.Close()
 		if err != nil {
-			log.Println("Error closing request body:", err)
+			logger.Printf("Error closing request body: %v", err)
 		}
 	}(req.Body)
 
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
-		return errors.New("line: webhook request body is null")
+		return nil, errors.New("line: webhook request body is null")
 	}
-
-	signature := req.Header.Get("x-line-signature")
-	return d.DispatchBody(o.ctx, signature, body)
+	return body, nil
 }
 
 func (d *Dispatcher) DispatchBody(ctx context.Context, signature string, body []byte) error {
@@ -80,47 +165,175 @@ func (d *Dispatcher) DispatchBody(ctx context.Context, signature string, body []
 	return d.Dispatch(ctx, events)
 }
 
+// getLogger returns log.Default() for a Dispatcher not made by NewDispatcher.
+func (d *Dispatcher) getLogger() Logger {
+	if d.logger == nil {
+		return log.Default()
+	}
+	return d.logger
+}
+
 // NewDispatcher returns a new Dispatcher instance.
 func NewDispatcher(opts ...Option) *Dispatcher {
-	dispatcher := &Dispatcher{}
+	dispatcher := &Dispatcher{logger: log.Default()}
 	for _, opt := range opts {
 		opt(dispatcher)
 	}
 	return dispatcher
 }
 
+// Dispatch runs the listeners of each event in order, and stops at the
+// first event a listener fails on. Events without a listener for their type,
+// including UnknownEvent, go to the FallbackListeners.
 func (d *Dispatcher) Dispatch(ctx context.Context, events []any) error {
 	for _, event := range events {
+		var (
+			handled bool
+			err     error
+		)
 		switch e := event.(type) {
 		case *FollowEvent:
-			return d.registerFollow(ctx, e)
+			handled, err = d.registerFollow(ctx, e)
 		case *UnFollowEvent:
-			return d.registerUnFollow(ctx, e)
-		default:
-			return errors.New("line: webhook dispatcher unsupported event")
+			handled, err = d.registerUnFollow(ctx, e)
+		case *MessageEvent:
+			handled, err = d.registerMessage(ctx, e)
+		case *PostbackEvent:
+			handled, err = d.registerPostback(ctx, e)
+		case *JoinEvent:
+			handled, err = d.registerJoin(ctx, e)
+		case *LeaveEvent:
+			handled, err = d.registerLeave(ctx, e)
+		case *MemberJoinedEvent:
+			handled, err = d.registerMemberJoined(ctx, e)
+		case *MemberLeftEvent:
+			handled, err = d.registerMemberLeft(ctx, e)
+		case *UnsendEvent:
+			handled, err = d.registerUnsend(ctx, e)
+		case *BeaconEvent:
+			handled, err = d.registerBeacon(ctx, e)
+		case *AccountLinkEvent:
+			handled, err = d.registerAccountLink(ctx, e)
+		case *VideoPlayCompleteEvent:
+			handled, err = d.registerVideoPlayComplete(ctx, e)
+		case *ThingsEvent:
+			handled, err = d.registerThings(ctx, e)
+		case *ActivatedEvent:
+			handled, err = d.registerActivated(ctx, e)
+		case *DeactivatedEvent:
+			handled, err = d.registerDeactivated(ctx, e)
+		case *BotSuspendedEvent:
+			handled, err = d.registerBotSuspended(ctx, e)
+		case *BotResumedEvent:
+			handled, err = d.registerBotResumed(ctx, e)
+		case *MembershipEvent:
+			handled, err = d.registerMembership(ctx, e)
+		}
+		if err == nil && !handled {
+			err = d.registerFallback(ctx, event)
+		}
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func (d *Dispatcher) registerFollow(ctx context.Context, event *FollowEvent) error {
-	eg, ctx := errgroup.WithContext(ctx)
-	for _, listener := range d.followListeners {
-		eg.Go(func() error {
-			return listener.OnFollow(ctx, event)
-		})
+func (d *Dispatcher) registerFollow(ctx context.Context, event *FollowEvent) (bool, error) {
+	return notify(ctx, d.followListeners, event, FollowListener.OnFollow)
+}
+
+func (d *Dispatcher) registerUnFollow(ctx context.Context, event *UnFollowEvent) (bool, error) {
+	return notify(ctx, d.unFollowListeners, event, UnFollowListener.OnUnFollow)
+}
+
+func (d *Dispatcher) registerMessage(ctx context.Context, event *MessageEvent) (bool, error) {
+	return notify(ctx, d.messageListeners, event, MessageListener.OnMessage)
+}
+
+func (d *Dispatcher) registerPostback(ctx context.Context, event *PostbackEvent) (bool, error) {
+	return notify(ctx, d.postbackListeners, event, PostbackListener.OnPostback)
+}
+
+func (d *Dispatcher) registerJoin(ctx context.Context, event *JoinEvent) (bool, error) {
+	return notify(ctx, d.joinListeners, event, JoinListener.OnJoin)
+}
+
+func (d *Dispatcher) registerLeave(ctx context.Context, event *LeaveEvent) (bool, error) {
+	return notify(ctx, d.leaveListeners, event, LeaveListener.OnLeave)
+}
+
+func (d *Dispatcher) registerMemberJoined(ctx context.Context, event *MemberJoinedEvent) (bool, error) {
+	return notify(ctx, d.memberJoinedListeners, event, MemberJoinedListener.OnMemberJoined)
+}
+
+func (d *Dispatcher) registerMemberLeft(ctx context.Context, event *MemberLeftEvent) (bool, error) {
+	return notify(ctx, d.memberLeftListeners, event, MemberLeftListener.OnMemberLeft)
+}
+
+func (d *Dispatcher) registerUnsend(ctx context.Context, event *UnsendEvent) (bool, error) {
+	return notify(ctx, d.unsendListeners, event, UnsendListener.OnUnsend)
+}
+
+func (d *Dispatcher) registerBeacon(ctx context.Context, event *BeaconEvent) (bool, error) {
+	return notify(ctx, d.beaconListeners, event, BeaconListener.OnBeacon)
+}
+
+func (d *Dispatcher) registerAccountLink(ctx context.Context, event *AccountLinkEvent) (bool, error) {
+	return notify(ctx, d.accountLinkListeners, event, AccountLinkListener.OnAccountLink)
+}
+
+func (d *Dispatcher) registerVideoPlayComplete(ctx context.Context, event *VideoPlayCompleteEvent) (bool, error) {
+	return notify(ctx, d.videoPlayCompleteListeners, event, VideoPlayCompleteListener.OnVideoPlayComplete)
+}
+
+func (d *Dispatcher) registerThings(ctx context.Context, event *ThingsEvent) (bool, error) {
+	return notify(ctx, d.thingsListeners, event, ThingsListener.OnThings)
+}
+
+func (d *Dispatcher) registerActivated(ctx context.Context, event *ActivatedEvent) (bool, error) {
+	return notify(ctx, d.activatedListeners, event, ActivatedListener.OnActivated)
+}
+
+func (d *Dispatcher) registerDeactivated(ctx context.Context, event *DeactivatedEvent) (bool, error) {
+	return notify(ctx, d.deactivatedListeners, event, DeactivatedListener.OnDeactivated)
+}
+
+func (d *Dispatcher) registerBotSuspended(ctx context.Context, event *BotSuspendedEvent) (bool, error) {
+	return notify(ctx, d.botSuspendedListeners, event, BotSuspendedListener.OnBotSuspended)
+}
+
+func (d *Dispatcher) registerBotResumed(ctx context.Context, event *BotResumedEvent) (bool, error) {
+	return notify(ctx, d.botResumedListeners, event, BotResumedListener.OnBotResumed)
+}
+
+func (d *Dispatcher) registerMembership(ctx context.Context, event *MembershipEvent) (bool, error) {
+	return notify(ctx, d.membershipListeners, event, MembershipListener.OnMembership)
+}
+
+func (d *Dispatcher) registerFallback(ctx context.Context, event any) error {
+	handled, err := notify(ctx, d.fallbackListeners, event, FallbackListener.OnEvent)
+	if !handled {
+		if unknown, ok := event.(*UnknownEvent); ok {
+			d.getLogger().Printf("line: webhook event type [%s] has no listener, skipping", unknown.Type)
+		}
 	}
-	return eg.Wait()
+	return err
 }
 
-func (d *Dispatcher) registerUnFollow(ctx context.Context, event *UnFollowEvent) error {
+// notify runs the listeners concurrently and returns the first error. It
+// reports whether there was any listener.
+func notify[L any, E any](ctx context.Context, listeners []L, event E, on func(L, context.Context, E) error) (bool, error) {
+	if len(listeners) == 0 {
+		return false, nil
+	}
 	eg, ctx := errgroup.WithContext(ctx)
-	for _, listener := range d.unFollowListeners {
+	for _, listener := range listeners {
 		eg.Go(func() error {
-			return listener.OnUnFollow(ctx, event)
+			return on(listener, ctx, event)
 		})
 	}
-	return eg.Wait()
+	return true, eg.Wait()
 }
 
 func (d *Dispatcher) FollowListener(listeners ...FollowListener) {
@@ -130,3 +343,71 @@ func (d *Dispatcher) FollowListener(listeners ...FollowListener) {
 func (d *Dispatcher) UnFollowListener(listeners ...UnFollowListener) {
 	d.unFollowListeners = append(d.unFollowListeners, listeners...)
 }
+
+func (d *Dispatcher) MessageListener(listeners ...MessageListener) {
+	d.messageListeners = append(d.messageListeners, listeners...)
+}
+
+func (d *Dispatcher) PostbackListener(listeners ...PostbackListener) {
+	d.postbackListeners = append(d.postbackListeners, listeners...)
+}
+
+func (d *Dispatcher) JoinListener(listeners ...JoinListener) {
+	d.joinListeners = append(d.joinListeners, listeners...)
+}
+
+func (d *Dispatcher) LeaveListener(listeners ...LeaveListener) {
+	d.leaveListeners = append(d.leaveListeners, listeners...)
+}
+
+func (d *Dispatcher) MemberJoinedListener(listeners ...MemberJoinedListener) {
+	d.memberJoinedListeners = append(d.memberJoinedListeners, listeners...)
+}
+
+func (d *Dispatcher) MemberLeftListener(listeners ...MemberLeftListener) {
+	d.memberLeftListeners = append(d.memberLeftListeners, listeners...)
+}
+
+func (d *Dispatcher) UnsendListener(listeners ...UnsendListener) {
+	d.unsendListeners = append(d.unsendListeners, listeners...)
+}
+
+func (d *Dispatcher) BeaconListener(listeners ...BeaconListener) {
+	d.beaconListeners = append(d.beaconListeners, listeners...)
+}
+
+func (d *Dispatcher) AccountLinkListener(listeners ...AccountLinkListener) {
+	d.accountLinkListeners = append(d.accountLinkListeners, listeners...)
+}
+
+func (d *Dispatcher) VideoPlayCompleteListener(listeners ...VideoPlayCompleteListener) {
+	d.videoPlayCompleteListeners = append(d.videoPlayCompleteListeners, listeners...)
+}
+
+func (d *Dispatcher) ThingsListener(listeners ...ThingsListener) {
+	d.thingsListeners = append(d.thingsListeners, listeners...)
+}
+
+func (d *Dispatcher) ActivatedListener(listeners ...ActivatedListener) {
+	d.activatedListeners = append(d.activatedListeners, listeners...)
+}
+
+func (d *Dispatcher) DeactivatedListener(listeners ...DeactivatedListener) {
+	d.deactivatedListeners = append(d.deactivatedListeners, listeners...)
+}
+
+func (d *Dispatcher) BotSuspendedListener(listeners ...BotSuspendedListener) {
+	d.botSuspendedListeners = append(d.botSuspendedListeners, listeners...)
+}
+
+func (d *Dispatcher) BotResumedListener(listeners ...BotResumedListener) {
+	d.botResumedListeners = append(d.botResumedListeners, listeners...)
+}
+
+func (d *Dispatcher) MembershipListener(listeners ...MembershipListener) {
+	d.membershipListeners = append(d.membershipListeners, listeners...)
+}
+
+func (d *Dispatcher) FallbackListener(listeners ...FallbackListener) {
+	d.fallbackListeners = append(d.fallbackListeners, listeners...)
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"log"
 )
 
 // EventType represents the type of webhook event.
@@ -19,6 +18,37 @@ const (
 
 	EventTypeFollow   EventType = "follow"
 	EventTypeUnFollow EventType = "unfollow"
+	EventTypeMessage  EventType = "message"
+	EventTypePostback EventType = "postback"
+
+	// ========================================
+	// 群组/聊天室类
+	// ========================================
+
+	EventTypeJoin         EventType = "join"
+	EventTypeLeave        EventType = "leave"
+	EventTypeMemberJoined EventType = "memberJoined"
+	EventTypeMemberLeft   EventType = "memberLeft"
+	EventTypeUnsend       EventType = "unsend"
+
+	// ========================================
+	// 设备/账号类
+	// ========================================
+
+	EventTypeBeacon            EventType = "beacon"
+	EventTypeAccountLink       EventType = "accountLink"
+	EventTypeVideoPlayComplete EventType = "videoPlayComplete"
+	EventTypeThings            EventType = "things"
+
+	// ========================================
+	// 模块/会员类
+	// ========================================
+
+	EventTypeActivated    EventType = "activated"
+	EventTypeDeactivated  EventType = "deactivated"
+	EventTypeBotSuspended EventType = "botSuspended"
+	EventTypeBotResumed   EventType = "botResumed"
+	EventTypeMembership   EventType = "membership"
 )
 
 func (e EventType) String() string {
@@ -40,62 +70,92 @@ func validateWebhookEvent(secret string, signature string, body []byte) error {
 	return errors.New("line: webhook compare error")
 }
 
-// ParseWebhookEvent parses the webhook event from the payload.
+// ParseWebhookEvent parses the webhook event from the payload. Events of
+// types this package does not know are returned as *UnknownEvent.
 func ParseWebhookEvent(secret string, signature string, body []byte) ([]any, error) {
 	err := validateWebhookEvent(secret, signature, body)
 	if err != nil {
 		return nil, err
 	}
 
-	var raw map[string]interface{}
+	var raw struct {
+		Events []json.RawMessage `json:"events"`
+	}
 	if err := json.Unmarshal(body, &raw); err != nil {
 		return nil, err
 	}
 
 	// check event
-	events, ok := raw["events"].([]interface{})
-	if !ok || len(events) == 0 {
+	if len(raw.Events) == 0 {
 		return nil, errors.New("line: webhook events not found or empty")
 	}
-	var parsedEvents []any
+	parsedEvents := make([]any, 0, len(raw.Events))
 
 	// get webhook type
-	for _, event := range events {
-		eventMap, ok := event.(map[string]interface{})
-		if !ok {
+	for _, event := range raw.Events {
+		var probe struct {
+			Type *string `json:"type"`
+		}
+		if err := json.Unmarshal(event, &probe); err != nil {
 			return nil, errors.New("line: event is not a valid map")
 		}
-
-		eventType, ok := eventMap["type"].(string)
-		if !ok {
+		if probe.Type == nil {
 			return nil, errors.New("line: event type not found or not a string")
 		}
 
-		eventStr, err := json.Marshal(eventMap)
+		parsedEvent, err := decodeEvent(EventType(*probe.Type), event)
 		if err != nil {
 			return nil, err
 		}
-		switch EventType(eventType) {
-		case EventTypeFollow:
-			followEvent, err := decodeWebhookEvent[FollowEvent](eventStr)
-			if err != nil {
-				return nil, err
-			}
-			parsedEvents = append(parsedEvents, followEvent)
-		case EventTypeUnFollow:
-			unFollowEvent, err := decodeWebhookEvent[UnFollowEvent](eventStr)
-			if err != nil {
-				return nil, err
-			}
-			parsedEvents = append(parsedEvents, unFollowEvent)
-		default: // todo: add more event types
-			log.Printf("line: webhook event type [%s] not supported, skipping", eventType)
-			continue
-		}
+		parsedEvents = append(parsedEvents, parsedEvent)
 	}
 	return parsedEvents, nil
 }
 
+// decodeEvent decodes an event of the given type, or returns an UnknownEvent
+// for types this package does not know.
+func decodeEvent(eventType EventType, body []byte) (any, error) {
+	switch eventType {
+	case EventTypeFollow:
+		return decodeWebhookEvent[FollowEvent](body)
+	case EventTypeUnFollow:
+		return decodeWebhookEvent[UnFollowEvent](body)
+	case EventTypeMessage:
+		return decodeWebhookEvent[MessageEvent](body)
+	case EventTypePostback:
+		return decodeWebhookEvent[PostbackEvent](body)
+	case EventTypeJoin:
+		return decodeWebhookEvent[JoinEvent](body)
+	case EventTypeLeave:
+		return decodeWebhookEvent[LeaveEvent](body)
+	case EventTypeMemberJoined:
+		return decodeWebhookEvent[MemberJoinedEvent](body)
+	case EventTypeMemberLeft:
+		return decodeWebhookEvent[MemberLeftEvent](body)
+	case EventTypeUnsend:
+		return decodeWebhookEvent[UnsendEvent](body)
+	case EventTypeBeacon:
+		return decodeWebhookEvent[BeaconEvent](body)
+	case EventTypeAccountLink:
+		return decodeWebhookEvent[AccountLinkEvent](body)
+	case EventTypeVideoPlayComplete:
+		return decodeWebhookEvent[VideoPlayCompleteEvent](body)
+	case EventTypeThings:
+		return decodeWebhookEvent[ThingsEvent](body)
+	case EventTypeActivated:
+		return decodeWebhookEvent[ActivatedEvent](body)
+	case EventTypeDeactivated:
+		return decodeWebhookEvent[DeactivatedEvent](body)
+	case EventTypeBotSuspended:
+		return decodeWebhookEvent[BotSuspendedEvent](body)
+	case EventTypeBotResumed:
+		return decodeWebhookEvent[BotResumedEvent](body)
+	case EventTypeMembership:
+		return decodeWebhookEvent[MembershipEvent](body)
+	}
+	return &UnknownEvent{Type: eventType, Raw: append(json.RawMessage(nil), body...)}, nil
+}
+
 func decodeWebhookEvent[T any](body []byte) (*T, error) {
 	var event T
 	if err := json.Unmarshal(body, &event); err != nil {
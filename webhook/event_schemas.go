@@ -1,5 +1,10 @@
 package webhook
 
+import (
+	"encoding/json"
+	"errors"
+)
+
 type FollowEvent struct {
 	ReplyToken      string          `json:"replyToken,omitempty"`
 	Type            string          `json:"type,omitempty"`
@@ -20,9 +25,223 @@ type UnFollowEvent struct {
 	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
 }
 
+type MessageEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Message         MessageContent  `json:"message,omitempty"`
+}
+
+func (e *MessageEvent) UnmarshalJSON(data []byte) error {
+	type alias MessageEvent
+	raw := struct {
+		*alias
+		Message json.RawMessage `json:"message"`
+	}{alias: (*alias)(e)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Message) == 0 {
+		return errors.New("line: webhook message event has no message")
+	}
+
+	var err error
+	e.Message, err = decodeMessageContent(raw.Message)
+	return err
+}
+
+type PostbackEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Postback        Postback        `json:"postback,omitempty"`
+}
+
+type JoinEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+}
+
+type LeaveEvent struct {
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+}
+
+type MemberJoinedEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Joined          Members         `json:"joined,omitempty"`
+}
+
+type MemberLeftEvent struct {
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Left            Members         `json:"left,omitempty"`
+}
+
+type UnsendEvent struct {
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Unsend          Unsend          `json:"unsend,omitempty"`
+}
+
+type BeaconEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Beacon          Beacon          `json:"beacon,omitempty"`
+}
+
+// AccountLinkEvent has no ReplyToken if the link token was invalid.
+type AccountLinkEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Link            AccountLink     `json:"link,omitempty"`
+}
+
+type VideoPlayCompleteEvent struct {
+	ReplyToken        string            `json:"replyToken,omitempty"`
+	Type              string            `json:"type,omitempty"`
+	Mode              string            `json:"mode,omitempty"`
+	Timestamp         int64             `json:"timestamp,omitempty"`
+	Source            Source            `json:"source,omitempty"`
+	WebhookEventID    string            `json:"webhookEventId,omitempty"`
+	DeliveryContext   DeliveryContext   `json:"deliveryContext,omitempty"`
+	VideoPlayComplete VideoPlayComplete `json:"videoPlayComplete,omitempty"`
+}
+
+type ThingsEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Things          Things          `json:"things,omitempty"`
+}
+
+// ActivatedEvent is sent to a module channel when it acquires chat control.
+type ActivatedEvent struct {
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	ChatControl     ChatControl     `json:"chatControl,omitempty"`
+}
+
+// DeactivatedEvent is sent to a module channel when it loses chat control.
+type DeactivatedEvent struct {
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+}
+
+// BotSuspendedEvent is sent to a module channel when the LINE Official
+// Account it is attached to is suspended.
+type BotSuspendedEvent struct {
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+}
+
+type BotResumedEvent struct {
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+}
+
+type MembershipEvent struct {
+	ReplyToken      string          `json:"replyToken,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Mode            string          `json:"mode,omitempty"`
+	Timestamp       int64           `json:"timestamp,omitempty"`
+	Source          Source          `json:"source,omitempty"`
+	WebhookEventID  string          `json:"webhookEventId,omitempty"`
+	DeliveryContext DeliveryContext `json:"deliveryContext,omitempty"`
+	Membership      Membership      `json:"membership,omitempty"`
+}
+
+// UnknownEvent keeps an event of a type added to LINE after this package,
+// so that it can still be handled by a FallbackListener.
+type UnknownEvent struct {
+	Type EventType
+	Raw  json.RawMessage
+}
+
+// Source is where the event happened. Type is "user", "group" or "room";
+// GroupID and RoomID are set for the latter two, and UserID only if the user
+// consented to sharing it.
 type Source struct {
-	Type   string `json:"type,omitempty"`
-	UserID string `json:"userId,omitempty"`
+	Type    string `json:"type,omitempty"`
+	UserID  string `json:"userId,omitempty"`
+	GroupID string `json:"groupId,omitempty"`
+	RoomID  string `json:"roomId,omitempty"`
+}
+
+const (
+	SourceTypeUser  = "user"
+	SourceTypeGroup = "group"
+	SourceTypeRoom  = "room"
+)
+
+// ChatID returns the ID to reply or push to: the group, room or user ID.
+func (s Source) ChatID() string {
+	switch s.Type {
+	case SourceTypeGroup:
+		return s.GroupID
+	case SourceTypeRoom:
+		return s.RoomID
+	}
+	return s.UserID
 }
 
 type DeliveryContext struct {
@@ -32,3 +251,97 @@ type DeliveryContext struct {
 type Follow struct {
 	IsUnblocked bool `json:"isUnblocked,omitempty"`
 }
+
+type Postback struct {
+	Data   string          `json:"data,omitempty"`
+	Params *PostbackParams `json:"params,omitempty"`
+}
+
+// PostbackParams holds what the user picked in a datetime picker action, or
+// the result of a rich menu switch action.
+type PostbackParams struct {
+	Date               string `json:"date,omitempty"`     // 2017-12-25
+	Time               string `json:"time,omitempty"`     // 01:00
+	Datetime           string `json:"datetime,omitempty"` // 2017-12-25T01:00
+	NewRichMenuAliasID string `json:"newRichMenuAliasId,omitempty"`
+	Status             string `json:"status,omitempty"`
+}
+
+type Members struct {
+	Members []Source `json:"members,omitempty"`
+}
+
+type Unsend struct {
+	MessageID string `json:"messageId,omitempty"`
+}
+
+const (
+	BeaconTypeEnter  = "enter"
+	BeaconTypeBanner = "banner"
+	BeaconTypeStay   = "stay"
+)
+
+type Beacon struct {
+	Hwid string `json:"hwid,omitempty"`
+	Type string `json:"type,omitempty"`
+	Dm   string `json:"dm,omitempty"` // Device message, hex encoded
+}
+
+const (
+	AccountLinkResultOK     = "ok"
+	AccountLinkResultFailed = "failed"
+)
+
+type AccountLink struct {
+	Result string `json:"result,omitempty"`
+	Nonce  string `json:"nonce,omitempty"`
+}
+
+type VideoPlayComplete struct {
+	TrackingID string `json:"trackingId,omitempty"`
+}
+
+const (
+	ThingsTypeLink           = "link"
+	ThingsTypeUnlink         = "unlink"
+	ThingsTypeScenarioResult = "scenarioResult"
+)
+
+// Things is a LINE Things device event; Result is only set for
+// scenarioResult.
+type Things struct {
+	DeviceID string                `json:"deviceId,omitempty"`
+	Type     string                `json:"type,omitempty"`
+	Result   *ThingsScenarioResult `json:"result,omitempty"`
+}
+
+type ThingsScenarioResult struct {
+	ScenarioID             string               `json:"scenarioId,omitempty"`
+	Revision               int                  `json:"revision,omitempty"`
+	StartTime              int64                `json:"startTime,omitempty"`
+	EndTime                int64                `json:"endTime,omitempty"`
+	ResultCode             string               `json:"resultCode,omitempty"`
+	ActionResults          []ThingsActionResult `json:"actionResults,omitempty"`
+	BleNotificationPayload string               `json:"bleNotificationPayload,omitempty"` // Base64 encoded
+	ErrorReason            string               `json:"errorReason,omitempty"`
+}
+
+type ThingsActionResult struct {
+	Type string `json:"type,omitempty"`
+	Data string `json:"data,omitempty"` // Base64 encoded
+}
+
+type ChatControl struct {
+	ExpireAt int64 `json:"expireAt,omitempty"` // Unix milliseconds
+}
+
+const (
+	MembershipTypeJoined  = "joined"
+	MembershipTypeLeft    = "left"
+	MembershipTypeRenewed = "renewed"
+)
+
+type Membership struct {
+	Type         string `json:"type,omitempty"`
+	MembershipID int64  `json:"membershipId,omitempty"`
+}
@@ -0,0 +1,258 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func sign(body string) string {
+	hash := hmac.New(sha256.New, []byte(testSecret))
+	hash.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+func parse(t *testing.T, events string) []any {
+	body := `{"destination":"U0","events":[` + events + `]}`
+	parsed, err := ParseWebhookEvent(testSecret, sign(body), []byte(body))
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestParseMessageEvent(t *testing.T) {
+	events := parse(t, `
+		{"type":"message","replyToken":"r1","source":{"type":"user","userId":"U1"},"timestamp":1,"mode":"active",
+		 "message":{"id":"1","type":"text","quoteToken":"q1","text":"@All hi $","quotedMessageId":"0",
+		  "emojis":[{"index":8,"length":1,"productId":"p","emojiId":"001"}],
+		  "mention":{"mentionees":[{"index":0,"length":4,"type":"all"}]}}},
+		{"type":"message","message":{"id":"2","type":"image","contentProvider":{"type":"line"},"imageSet":{"id":"set","index":1,"total":2}}},
+		{"type":"message","message":{"id":"3","type":"video","duration":1000,"contentProvider":{"type":"external","originalContentUrl":"https://example.com/a.mp4"}}},
+		{"type":"message","message":{"id":"4","type":"audio","duration":2000,"contentProvider":{"type":"line"}}},
+		{"type":"message","message":{"id":"5","type":"file","fileName":"a.pdf","fileSize":42}},
+		{"type":"message","message":{"id":"6","type":"location","title":"here","address":"there","latitude":35.6,"longitude":139.7}},
+		{"type":"message","message":{"id":"7","type":"sticker","packageId":"1","stickerId":"2","stickerResourceType":"ANIMATION","keywords":["hi"]}},
+		{"type":"message","message":{"id":"8","type":"hologram","depth":3}}`)
+	require.Len(t, events, 8)
+
+	text := events[0].(*MessageEvent)
+	assert.Equal(t, "r1", text.ReplyToken)
+	assert.Equal(t, "U1", text.Source.UserID)
+	assert.Equal(t, &TextMessageContent{
+		ID: "1", Type: "text", QuoteToken: "q1", Text: "@All hi $", QuotedMessageID: "0",
+		Emojis:  []Emoji{{Index: 8, Length: 1, ProductID: "p", EmojiID: "001"}},
+		Mention: &Mention{Mentionees: []Mentionee{{Index: 0, Length: 4, Type: "all"}}},
+	}, text.Message)
+
+	assert.Equal(t, &ImageSet{ID: "set", Index: 1, Total: 2}, events[1].(*MessageEvent).Message.(*ImageMessageContent).ImageSet)
+	assert.Equal(t, "https://example.com/a.mp4", events[2].(*MessageEvent).Message.(*VideoMessageContent).ContentProvider.OriginalContentURL)
+	assert.Equal(t, int64(2000), events[3].(*MessageEvent).Message.(*AudioMessageContent).Duration)
+	assert.Equal(t, "a.pdf", events[4].(*MessageEvent).Message.(*FileMessageContent).FileName)
+	assert.Equal(t, 139.7, events[5].(*MessageEvent).Message.(*LocationMessageContent).Longitude)
+	assert.Equal(t, []string{"hi"}, events[6].(*MessageEvent).Message.(*StickerMessageContent).Keywords)
+
+	unknown := events[7].(*MessageEvent).Message.(*UnknownMessageContent)
+	assert.Equal(t, MessageContentType("hologram"), unknown.MessageContentType())
+	assert.JSONEq(t, `{"id":"8","type":"hologram","depth":3}`, string(unknown.Raw))
+}
+
+type messageRecorder struct {
+	messages []MessageContent
+}
+
+func (r *messageRecorder) OnMessage(_ context.Context, event *MessageEvent) error {
+	r.messages = append(r.messages, event.Message)
+	return nil
+}
+
+func TestDispatchMessage(t *testing.T) {
+	recorder := &messageRecorder{}
+	d := NewDispatcher(WithSecret(testSecret), WithRegisters(recorder))
+
+	body := `{"destination":"U0","events":[{"type":"message","message":{"id":"1","type":"text","text":"hi"}}]}`
+	require.NoError(t, d.DispatchBody(context.Background(), sign(body), []byte(body)))
+	assert.Equal(t, []MessageContent{&TextMessageContent{ID: "1", Type: "text", Text: "hi"}}, recorder.messages)
+}
+
+type followRecorder struct {
+	userIDs []string
+}
+
+func (r *followRecorder) OnFollow(_ context.Context, event *FollowEvent) error {
+	r.userIDs = append(r.userIDs, event.Source.UserID)
+	return nil
+}
+
+func TestDispatchBatch(t *testing.T) {
+	follows := &followRecorder{}
+	messages := &messageRecorder{}
+	d := NewDispatcher(WithSecret(testSecret), WithRegisters(follows, messages))
+
+	body := `{"destination":"U0","events":[
+		{"type":"follow","source":{"type":"user","userId":"U1"}},
+		{"type":"message","message":{"id":"1","type":"text","text":"hi"}},
+		{"type":"message","message":{"id":"2","type":"text","text":"again"}},
+		{"type":"follow","source":{"type":"user","userId":"U2"}}]}`
+	require.NoError(t, d.DispatchBody(context.Background(), sign(body), []byte(body)))
+	assert.Equal(t, []string{"U1", "U2"}, follows.userIDs)
+	assert.Equal(t, []MessageContent{
+		&TextMessageContent{ID: "1", Type: "text", Text: "hi"},
+		&TextMessageContent{ID: "2", Type: "text", Text: "again"},
+	}, messages.messages)
+}
+
+func TestParseGroupEvents(t *testing.T) {
+	events := parse(t, `
+		{"type":"postback","replyToken":"r1","source":{"type":"user","userId":"U1"},
+		 "postback":{"data":"action=book","params":{"datetime":"2017-12-25T01:00"}}},
+		{"type":"postback","source":{"type":"user","userId":"U1"},
+		 "postback":{"data":"page=2","params":{"newRichMenuAliasId":"page-2","status":"SUCCESS"}}},
+		{"type":"join","replyToken":"r2","source":{"type":"group","groupId":"C1"}},
+		{"type":"leave","source":{"type":"room","roomId":"R1"}},
+		{"type":"memberJoined","replyToken":"r3","source":{"type":"group","groupId":"C1"},
+		 "joined":{"members":[{"type":"user","userId":"U2"}]}},
+		{"type":"memberLeft","source":{"type":"group","groupId":"C1"},
+		 "left":{"members":[{"type":"user","userId":"U3"}]}},
+		{"type":"unsend","source":{"type":"group","groupId":"C1","userId":"U2"},"unsend":{"messageId":"325708"}}`)
+	require.Len(t, events, 7)
+
+	postback := events[0].(*PostbackEvent)
+	assert.Equal(t, "action=book", postback.Postback.Data)
+	assert.Equal(t, &PostbackParams{Datetime: "2017-12-25T01:00"}, postback.Postback.Params)
+	assert.Equal(t, &PostbackParams{NewRichMenuAliasID: "page-2", Status: "SUCCESS"}, events[1].(*PostbackEvent).Postback.Params)
+
+	join := events[2].(*JoinEvent)
+	assert.Equal(t, "r2", join.ReplyToken)
+	assert.Equal(t, "C1", join.Source.ChatID())
+	assert.Equal(t, "R1", events[3].(*LeaveEvent).Source.ChatID())
+	assert.Equal(t, []Source{{Type: SourceTypeUser, UserID: "U2"}}, events[4].(*MemberJoinedEvent).Joined.Members)
+	assert.Equal(t, "U3", events[5].(*MemberLeftEvent).Left.Members[0].ChatID())
+
+	unsend := events[6].(*UnsendEvent)
+	assert.Equal(t, "325708", unsend.Unsend.MessageID)
+	assert.Equal(t, Source{Type: SourceTypeGroup, GroupID: "C1", UserID: "U2"}, unsend.Source)
+}
+
+type groupRecorder struct {
+	calls []string
+}
+
+func (r *groupRecorder) OnJoin(_ context.Context, event *JoinEvent) error {
+	r.calls = append(r.calls, "join "+event.Source.GroupID)
+	return nil
+}
+
+func (r *groupRecorder) OnMemberJoined(_ context.Context, event *MemberJoinedEvent) error {
+	r.calls = append(r.calls, "memberJoined "+event.Joined.Members[0].UserID)
+	return nil
+}
+
+func TestDispatchAllEvents(t *testing.T) {
+	recorder := &groupRecorder{}
+	d := NewDispatcher(WithSecret(testSecret), WithRegisters(recorder))
+
+	body := `{"destination":"U0","events":[
+		{"type":"join","source":{"type":"group","groupId":"C1"}},
+		{"type":"memberJoined","source":{"type":"group","groupId":"C1"},"joined":{"members":[{"type":"user","userId":"U2"}]}}]}`
+	require.NoError(t, d.DispatchBody(context.Background(), sign(body), []byte(body)))
+	assert.Equal(t, []string{"join C1", "memberJoined U2"}, recorder.calls)
+}
+
+func TestParseDeviceEvents(t *testing.T) {
+	events := parse(t, `
+		{"type":"beacon","replyToken":"r1","source":{"type":"user","userId":"U1"},
+		 "beacon":{"hwid":"d41d8cd98f","type":"enter","dm":"1234"}},
+		{"type":"accountLink","replyToken":"r2","source":{"type":"user","userId":"U1"},
+		 "link":{"result":"ok","nonce":"xxxxxxxxxxxxxxx"}},
+		{"type":"videoPlayComplete","replyToken":"r3","source":{"type":"user","userId":"U1"},
+		 "videoPlayComplete":{"trackingId":"track-id"}},
+		{"type":"things","source":{"type":"user","userId":"U1"},
+		 "things":{"deviceId":"t2016","type":"scenarioResult","result":{"scenarioId":"s1","revision":2,
+		  "startTime":1547817845950,"endTime":1547817845952,"resultCode":"success",
+		  "actionResults":[{"type":"binary","data":"/w=="}],"bleNotificationPayload":"AQ=="}}}`)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, Beacon{Hwid: "d41d8cd98f", Type: BeaconTypeEnter, Dm: "1234"}, events[0].(*BeaconEvent).Beacon)
+
+	link := events[1].(*AccountLinkEvent)
+	assert.Equal(t, "r2", link.ReplyToken)
+	assert.Equal(t, AccountLink{Result: AccountLinkResultOK, Nonce: "xxxxxxxxxxxxxxx"}, link.Link)
+
+	assert.Equal(t, "track-id", events[2].(*VideoPlayCompleteEvent).VideoPlayComplete.TrackingID)
+
+	things := events[3].(*ThingsEvent).Things
+	assert.Equal(t, ThingsTypeScenarioResult, things.Type)
+	require.NotNil(t, things.Result)
+	assert.Equal(t, "success", things.Result.ResultCode)
+	assert.Equal(t, []ThingsActionResult{{Type: "binary", Data: "/w=="}}, things.Result.ActionResults)
+}
+
+func TestParseModuleEvents(t *testing.T) {
+	events := parse(t, `
+		{"type":"activated","mode":"active","source":{"type":"user","userId":"U1"},"chatControl":{"expireAt":1609459200000}},
+		{"type":"deactivated","mode":"standby","source":{"type":"user","userId":"U1"}},
+		{"type":"botSuspended","mode":"active","timestamp":1},
+		{"type":"botResumed","mode":"active","timestamp":2},
+		{"type":"membership","replyToken":"r1","source":{"type":"user","userId":"U1"},"membership":{"type":"renewed","membershipId":3189}}`)
+	require.Len(t, events, 5)
+
+	assert.Equal(t, int64(1609459200000), events[0].(*ActivatedEvent).ChatControl.ExpireAt)
+	assert.Equal(t, "standby", events[1].(*DeactivatedEvent).Mode)
+	assert.Equal(t, int64(1), events[2].(*BotSuspendedEvent).Timestamp)
+	assert.Equal(t, int64(2), events[3].(*BotResumedEvent).Timestamp)
+	assert.Equal(t, Membership{Type: MembershipTypeRenewed, MembershipID: 3189}, events[4].(*MembershipEvent).Membership)
+}
+
+type fallbackRecorder struct {
+	events []any
+}
+
+func (r *fallbackRecorder) OnEvent(_ context.Context, event any) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+type logRecorder struct {
+	lines []string
+}
+
+func (r *logRecorder) Printf(format string, v ...any) {
+	r.lines = append(r.lines, fmt.Sprintf(format, v...))
+}
+
+func TestDispatchUnknownEvent(t *testing.T) {
+	body := `{"destination":"U0","events":[
+		{"type":"hologram","depth":3},
+		{"type":"follow","source":{"type":"user","userId":"U1"}},
+		{"type":"message","message":{"id":"1","type":"text","text":"hi"}}]}`
+
+	events, err := ParseWebhookEvent(testSecret, sign(body), []byte(body))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	unknown := events[0].(*UnknownEvent)
+	assert.Equal(t, EventType("hologram"), unknown.Type)
+	assert.JSONEq(t, `{"type":"hologram","depth":3}`, string(unknown.Raw))
+
+	// The fallback gets the events that no typed listener handles.
+	fallback := &fallbackRecorder{}
+	messages := &messageRecorder{}
+	d := NewDispatcher(WithSecret(testSecret), WithRegisters(fallback, messages))
+	require.NoError(t, d.DispatchBody(context.Background(), sign(body), []byte(body)))
+	require.Len(t, fallback.events, 2)
+	assert.Equal(t, unknown, fallback.events[0])
+	assert.IsType(t, &FollowEvent{}, fallback.events[1])
+	assert.Len(t, messages.messages, 1)
+
+	// Without a fallback, unknown events are logged instead.
+	logger := &logRecorder{}
+	d = NewDispatcher(WithSecret(testSecret), WithLogger(logger))
+	require.NoError(t, d.DispatchBody(context.Background(), sign(body), []byte(body)))
+	assert.Equal(t, []string{"line: webhook event type [hologram] has no listener, skipping"}, logger.lines)
+}
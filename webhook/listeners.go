@@ -11,4 +11,83 @@ type (
 	UnFollowListener interface {
 		OnUnFollow(ctx context.Context, event *UnFollowEvent) error
 	}
+
+	MessageListener interface {
+		OnMessage(ctx context.Context, event *MessageEvent) error
+	}
+
+	PostbackListener interface {
+		OnPostback(ctx context.Context, event *PostbackEvent) error
+	}
+)
+
+// 群组/聊天室类
+type (
+	JoinListener interface {
+		OnJoin(ctx context.Context, event *JoinEvent) error
+	}
+
+	LeaveListener interface {
+		OnLeave(ctx context.Context, event *LeaveEvent) error
+	}
+
+	MemberJoinedListener interface {
+		OnMemberJoined(ctx context.Context, event *MemberJoinedEvent) error
+	}
+
+	MemberLeftListener interface {
+		OnMemberLeft(ctx context.Context, event *MemberLeftEvent) error
+	}
+
+	UnsendListener interface {
+		OnUnsend(ctx context.Context, event *UnsendEvent) error
+	}
 )
+
+// 设备/账号类
+type (
+	BeaconListener interface {
+		OnBeacon(ctx context.Context, event *BeaconEvent) error
+	}
+
+	AccountLinkListener interface {
+		OnAccountLink(ctx context.Context, event *AccountLinkEvent) error
+	}
+
+	VideoPlayCompleteListener interface {
+		OnVideoPlayComplete(ctx context.Context, event *VideoPlayCompleteEvent) error
+	}
+
+	ThingsListener interface {
+		OnThings(ctx context.Context, event *ThingsEvent) error
+	}
+)
+
+// 模块/会员类
+type (
+	ActivatedListener interface {
+		OnActivated(ctx context.Context, event *ActivatedEvent) error
+	}
+
+	DeactivatedListener interface {
+		OnDeactivated(ctx context.Context, event *DeactivatedEvent) error
+	}
+
+	BotSuspendedListener interface {
+		OnBotSuspended(ctx context.Context, event *BotSuspendedEvent) error
+	}
+
+	BotResumedListener interface {
+		OnBotResumed(ctx context.Context, event *BotResumedEvent) error
+	}
+
+	MembershipListener interface {
+		OnMembership(ctx context.Context, event *MembershipEvent) error
+	}
+)
+
+// FallbackListener receives the events that have no listener for their
+// type, including UnknownEvent for types added to LINE after this package.
+type FallbackListener interface {
+	OnEvent(ctx context.Context, event any) error
+}
@@ -0,0 +1,19 @@
+package webhook
+
+// Logger receives the diagnostics of the webhook package, such as events
+// that no listener handled. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Printf(string, ...any) {}
+
+// orDiscard returns logger, or a Logger that drops everything if it is nil.
+func orDiscard(logger Logger) Logger {
+	if logger == nil {
+		return discardLogger{}
+	}
+	return logger
+}
@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"encoding/json"
+)
+
+// MessageContentType represents the type of the message of a MessageEvent.
+type MessageContentType string
+
+const (
+	MessageContentTypeText     MessageContentType = "text"
+	MessageContentTypeImage    MessageContentType = "image"
+	MessageContentTypeVideo    MessageContentType = "video"
+	MessageContentTypeAudio    MessageContentType = "audio"
+	MessageContentTypeFile     MessageContentType = "file"
+	MessageContentTypeLocation MessageContentType = "location"
+	MessageContentTypeSticker  MessageContentType = "sticker"
+)
+
+// MessageContent is the message of a MessageEvent, one of the
+// *XxxMessageContent types. Types this package does not know yet are
+// decoded as *UnknownMessageContent.
+type MessageContent interface {
+	MessageContentType() MessageContentType
+}
+
+type TextMessageContent struct {
+	ID              string   `json:"id,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	QuoteToken      string   `json:"quoteToken,omitempty"`
+	Text            string   `json:"text,omitempty"`
+	Emojis          []Emoji  `json:"emojis,omitempty"`
+	Mention         *Mention `json:"mention,omitempty"`
+	QuotedMessageID string   `json:"quotedMessageId,omitempty"`
+}
+
+type Emoji struct {
+	Index     int    `json:"index"`
+	Length    int    `json:"length"`
+	ProductID string `json:"productId,omitempty"`
+	EmojiID   string `json:"emojiId,omitempty"`
+}
+
+type Mention struct {
+	Mentionees []Mentionee `json:"mentionees,omitempty"`
+}
+
+// Mentionee is a mentioned user, or everyone when Type is "all".
+type Mentionee struct {
+	Index  int    `json:"index"`
+	Length int    `json:"length"`
+	Type   string `json:"type,omitempty"`
+	UserID string `json:"userId,omitempty"`
+	IsSelf bool   `json:"isSelf,omitempty"`
+}
+
+type ImageMessageContent struct {
+	ID              string          `json:"id,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	QuoteToken      string          `json:"quoteToken,omitempty"`
+	ContentProvider ContentProvider `json:"contentProvider,omitempty"`
+	ImageSet        *ImageSet       `json:"imageSet,omitempty"`
+}
+
+// ContentProvider tells whether the content is stored by LINE, to be fetched
+// with the message content API, or by an external provider.
+type ContentProvider struct {
+	Type               string `json:"type,omitempty"`
+	OriginalContentURL string `json:"originalContentUrl,omitempty"`
+	PreviewImageURL    string `json:"previewImageUrl,omitempty"`
+}
+
+// ImageSet groups images sent together; Total is missing until all of them
+// have been received.
+type ImageSet struct {
+	ID    string `json:"id,omitempty"`
+	Index int    `json:"index,omitempty"`
+	Total int    `json:"total,omitempty"`
+}
+
+type VideoMessageContent struct {
+	ID              string          `json:"id,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	QuoteToken      string          `json:"quoteToken,omitempty"`
+	Duration        int64           `json:"duration,omitempty"` // Milliseconds
+	ContentProvider ContentProvider `json:"contentProvider,omitempty"`
+}
+
+type AudioMessageContent struct {
+	ID              string          `json:"id,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Duration        int64           `json:"duration,omitempty"` // Milliseconds
+	ContentProvider ContentProvider `json:"contentProvider,omitempty"`
+}
+
+type FileMessageContent struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+	FileSize int64  `json:"fileSize,omitempty"` // Bytes
+}
+
+type LocationMessageContent struct {
+	ID        string  `json:"id,omitempty"`
+	Type      string  `json:"type,omitempty"`
+	Title     string  `json:"title,omitempty"`
+	Address   string  `json:"address,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+}
+
+type StickerMessageContent struct {
+	ID                  string   `json:"id,omitempty"`
+	Type                string   `json:"type,omitempty"`
+	QuoteToken          string   `json:"quoteToken,omitempty"`
+	PackageID           string   `json:"packageId,omitempty"`
+	StickerID           string   `json:"stickerId,omitempty"`
+	StickerResourceType string   `json:"stickerResourceType,omitempty"`
+	Keywords            []string `json:"keywords,omitempty"`
+	Text                string   `json:"text,omitempty"`
+	QuotedMessageID     string   `json:"quotedMessageId,omitempty"`
+}
+
+// UnknownMessageContent keeps a message of a type added to LINE after this
+// package.
+type UnknownMessageContent struct {
+	ID   string          `json:"id,omitempty"`
+	Type string          `json:"type,omitempty"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+func (TextMessageContent) MessageContentType() MessageContentType {
+	return MessageContentTypeText
+}
+
+func (ImageMessageContent) MessageContentType() MessageContentType {
+	return MessageContentTypeImage
+}
+
+func (VideoMessageContent) MessageContentType() MessageContentType {
+	return MessageContentTypeVideo
+}
+
+func (AudioMessageContent) MessageContentType() MessageContentType {
+	return MessageContentTypeAudio
+}
+
+func (FileMessageContent) MessageContentType() MessageContentType {
+	return MessageContentTypeFile
+}
+
+func (LocationMessageContent) MessageContentType() MessageContentType {
+	return MessageContentTypeLocation
+}
+
+func (StickerMessageContent) MessageContentType() MessageContentType {
+	return MessageContentTypeSticker
+}
+
+func (c UnknownMessageContent) MessageContentType() MessageContentType {
+	return MessageContentType(c.Type)
+}
+
+func decodeMessageContent(data []byte) (MessageContent, error) {
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	var content MessageContent
+	switch MessageContentType(probe.Type) {
+	case MessageContentTypeText:
+		content = new(TextMessageContent)
+	case MessageContentTypeImage:
+		content = new(ImageMessageContent)
+	case MessageContentTypeVideo:
+		content = new(VideoMessageContent)
+	case MessageContentTypeAudio:
+		content = new(AudioMessageContent)
+	case MessageContentTypeFile:
+		content = new(FileMessageContent)
+	case MessageContentTypeLocation:
+		content = new(LocationMessageContent)
+	case MessageContentTypeSticker:
+		content = new(StickerMessageContent)
+	default:
+		unknown := &UnknownMessageContent{Raw: append(json.RawMessage(nil), data...)}
+		if err := json.Unmarshal(data, unknown); err != nil {
+			return nil, err
+		}
+		return unknown, nil
+	}
+
+	if err := json.Unmarshal(data, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
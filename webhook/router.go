@@ -0,0 +1,166 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sync"
+)
+
+// ErrUnknownDestination is returned by Router for a payload whose
+// destination has no Dispatcher.
+var ErrUnknownDestination = errors.New("line: webhook unknown destination")
+
+// DispatcherLoader returns the Dispatcher of a destination that was not
+// registered up front. It returns ErrUnknownDestination if there is none.
+//
+// The loader runs before the signature is verified, since the secret comes
+// from the Dispatcher, so anyone can make the router call it with any
+// destination that looks like a bot user ID. Keep it cheap for unknown
+// destinations, e.g. by caching them. The router keeps a loaded Dispatcher
+// only once a request to it passes the signature check.
+type DispatcherLoader func(ctx context.Context, destination string) (*Dispatcher, error)
+
+// botUserID matches the destination of a webhook payload.
+var botUserID = regexp.MustCompile(`^U[0-9a-f]{32}$`)
+
+type RouterOption func(*Router)
+
+// WithRouterLogger replaces log.Default() as the logger of the router. A nil
+// logger discards everything.
+func WithRouterLogger(logger Logger) RouterOption {
+	return func(r *Router) {
+		r.logger = orDiscard(logger)
+	}
+}
+
+// WithDispatcherLoader sets the loader for destinations that were not
+// registered.
+func WithDispatcherLoader(loader DispatcherLoader) RouterOption {
+	return func(r *Router) {
+		r.loader = loader
+	}
+}
+
+// Router serves the webhooks of several channels from one endpoint. It
+// routes each payload by its destination, the user ID of the bot that
+// receives the events, to the Dispatcher holding that channel's secret. It
+// is safe for concurrent use.
+type Router struct {
+	loader DispatcherLoader
+	logger Logger
+
+	mu          sync.RWMutex
+	dispatchers map[string]*Dispatcher
+}
+
+// NewRouter returns a new Router instance.
+func NewRouter(opts ...RouterOption) *Router {
+	router := &Router{
+		dispatchers: make(map[string]*Dispatcher),
+		logger:      log.Default(),
+	}
+	for _, opt := range opts {
+		opt(router)
+	}
+	return router
+}
+
+// Register routes the events for the bot user ID destination to d.
+func (r *Router) Register(destination string, d *Dispatcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dispatchers[destination] = d
+}
+
+// Unregister removes the Dispatcher of destination.
+func (r *Router) Unregister(destination string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.dispatchers, destination)
+}
+
+func (r *Router) DispatchRequest(req *http.Request, opts ...DispatchRequestOption) error {
+	o := &dispatchRequestOptions{
+		ctx: req.Context(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	body, err := readRequestBody(req, r.logger)
+	if err != nil {
+		return err
+	}
+
+	signature := req.Header.Get("x-line-signature")
+	return r.DispatchBody(o.ctx, signature, body)
+}
+
+// DispatchBody verifies and dispatches body with the Dispatcher of its
+// destination.
+func (r *Router) DispatchBody(ctx context.Context, signature string, body []byte) error {
+	var payload struct {
+		Destination string `json:"destination"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+	if payload.Destination == "" {
+		return errors.New("line: webhook destination not found")
+	}
+
+	d, cached, err := r.dispatcher(ctx, payload.Destination)
+	if err != nil {
+		return err
+	}
+	if !cached {
+		// A forged request must not be able to fill the cache.
+		if err := validateWebhookEvent(d.secret, signature, body); err != nil {
+			return err
+		}
+		d = r.store(payload.Destination, d)
+	}
+	return d.DispatchBody(ctx, signature, body)
+}
+
+// dispatcher returns the Dispatcher of destination and whether it is
+// registered, or else loads it.
+func (r *Router) dispatcher(ctx context.Context, destination string) (*Dispatcher, bool, error) {
+	r.mu.RLock()
+	d, ok := r.dispatchers[destination]
+	r.mu.RUnlock()
+	if ok {
+		return d, true, nil
+	}
+
+	if r.loader == nil || !botUserID.MatchString(destination) {
+		return nil, false, fmt.Errorf("%w: %s", ErrUnknownDestination, destination)
+	}
+	d, err := r.loader(ctx, destination)
+	if err != nil {
+		return nil, false, err
+	}
+	if d == nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrUnknownDestination, destination)
+	}
+	return d, false, nil
+}
+
+// store registers a loaded Dispatcher, unless another was registered or
+// loaded meanwhile.
+func (r *Router) store(destination string, d *Dispatcher) *Dispatcher {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.dispatchers[destination]; ok {
+		return existing
+	}
+	r.dispatchers[destination] = d
+	return d
+}
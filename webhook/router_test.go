@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouterLoader(t *testing.T) {
+	destination := "U" + strings.Repeat("0", 32)
+	loads := 0
+	recorder := &messageRecorder{}
+	router := NewRouter(WithDispatcherLoader(func(ctx context.Context, destination string) (*Dispatcher, error) {
+		loads++
+		if destination == "U"+strings.Repeat("f", 32) {
+			return nil, nil
+		}
+		return NewDispatcher(WithSecret(testSecret), WithRegisters(recorder)), nil
+	}))
+
+	body := `{"destination":"` + destination + `","events":[{"type":"message","message":{"id":"1","type":"text","text":"hi"}}]}`
+
+	// A forged request loads the Dispatcher, but does not keep it.
+	for range 2 {
+		assert.Error(t, router.DispatchBody(context.Background(), sign("forged"), []byte(body)))
+	}
+	assert.Equal(t, 2, loads)
+	assert.Empty(t, recorder.messages)
+
+	for range 2 {
+		require.NoError(t, router.DispatchBody(context.Background(), sign(body), []byte(body)))
+	}
+	assert.Equal(t, 3, loads)
+	assert.Len(t, recorder.messages, 2)
+
+	// Destinations that are not bot user IDs never reach the loader, and a
+	// loader without a Dispatcher is an unknown destination.
+	for _, destination := range []string{"U0", "U" + strings.Repeat("f", 32)} {
+		body := `{"destination":"` + destination + `","events":[]}`
+		err := router.DispatchBody(context.Background(), sign(body), []byte(body))
+		assert.ErrorIs(t, err, ErrUnknownDestination)
+	}
+	assert.Equal(t, 4, loads)
+}